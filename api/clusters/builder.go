@@ -0,0 +1,296 @@
+package clusters
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/tcz001/databricks-sdk-go/models"
+)
+
+// ValidationErrors holds every violation found by SpecBuilder.Validate.
+type ValidationErrors []error
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+
+	return fmt.Sprintf("invalid cluster spec: %s", strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the individual violations, so that errors.Is and errors.As
+// match any of them on Go 1.20 and later.
+func (e ValidationErrors) Unwrap() []error {
+	return e
+}
+
+// SpecBuilder builds cluster create and edit requests:
+//
+//	request, err := clusters.NewSpecBuilder("10.4.x-scala2.12", "m5.large").
+//		WithName("etl").
+//		WithAutoscale(1, 8).
+//		WithSpot(1, 100).
+//		WithTags(map[string]string{"team": "data"}).
+//		Build()
+type SpecBuilder struct {
+	request models.ClustersCreateRequest
+}
+
+func NewSpecBuilder(sparkVersion string, nodeTypeId string) *SpecBuilder {
+	return &SpecBuilder{request: models.ClustersCreateRequest{
+		SparkVersion: sparkVersion,
+		NodeTypeId:   nodeTypeId,
+	}}
+}
+
+func (b *SpecBuilder) WithName(name string) *SpecBuilder {
+	b.request.ClusterName = name
+	return b
+}
+
+// WithNumWorkers sets a fixed cluster size and removes autoscaling.
+func (b *SpecBuilder) WithNumWorkers(numWorkers int32) *SpecBuilder {
+	b.request.NumWorkers = numWorkers
+	b.request.Autoscale = nil
+	return b
+}
+
+// WithAutoscale sets an autoscaling range and removes the fixed size.
+func (b *SpecBuilder) WithAutoscale(minWorkers int32, maxWorkers int32) *SpecBuilder {
+	b.request.NumWorkers = 0
+	b.request.Autoscale = &models.ClustersAutoScale{MinWorkers: minWorkers, MaxWorkers: maxWorkers}
+	return b
+}
+
+func (b *SpecBuilder) WithDriverNodeType(nodeTypeId string) *SpecBuilder {
+	b.request.DriverNodeTypeId = nodeTypeId
+	return b
+}
+
+func (b *SpecBuilder) awsAttributes() *models.ClustersAwsAttributes {
+	if b.request.AwsAttributes == nil {
+		b.request.AwsAttributes = &models.ClustersAwsAttributes{}
+	}
+
+	return b.request.AwsAttributes
+}
+
+// WithSpot uses AWS spot instances falling back to on-demand, keeping the
+// first firstOnDemand nodes, including the driver, on-demand.
+// bidPricePercent is the maximum price as a percentage of the on-demand price.
+func (b *SpecBuilder) WithSpot(firstOnDemand int32, bidPricePercent int32) *SpecBuilder {
+	availability := models.SPOT_WITH_FALLBACK
+	attributes := b.awsAttributes()
+	attributes.Availability = &availability
+	attributes.FirstOnDemand = firstOnDemand
+	attributes.SpotBidPricePercent = bidPricePercent
+	return b
+}
+
+// WithEbsVolumes attaches count AWS EBS volumes of sizeGB each to every node.
+func (b *SpecBuilder) WithEbsVolumes(volumeType models.ClustersEbsVolumeType, count int32, sizeGB int32) *SpecBuilder {
+	attributes := b.awsAttributes()
+	attributes.EbsVolumeType = &volumeType
+	attributes.EbsVolumeCount = count
+	attributes.EbsVolumeSize = sizeGB
+	return b
+}
+
+func (b *SpecBuilder) WithInstanceProfile(arn string) *SpecBuilder {
+	b.awsAttributes().InstanceProfileArn = arn
+	return b
+}
+
+func (b *SpecBuilder) WithAwsAttributes(attributes *models.ClustersAwsAttributes) *SpecBuilder {
+	b.request.AwsAttributes = attributes
+	return b
+}
+
+func (b *SpecBuilder) WithAzureAttributes(attributes *models.ClustersAzureAttributes) *SpecBuilder {
+	b.request.AzureAttributes = attributes
+	return b
+}
+
+func (b *SpecBuilder) WithGcpAttributes(attributes *models.ClustersGcpAttributes) *SpecBuilder {
+	b.request.GcpAttributes = attributes
+	return b
+}
+
+// WithTags adds custom tags, replacing existing tags with the same key.
+func (b *SpecBuilder) WithTags(tags map[string]string) *SpecBuilder {
+	b.request.CustomTags = merge(b.request.CustomTags, tags)
+	return b
+}
+
+// WithSparkConf adds Spark configuration properties.
+func (b *SpecBuilder) WithSparkConf(conf map[string]string) *SpecBuilder {
+	b.request.SparkConf = merge(b.request.SparkConf, conf)
+	return b
+}
+
+// WithSparkEnvVars adds environment variables.
+func (b *SpecBuilder) WithSparkEnvVars(vars map[string]string) *SpecBuilder {
+	b.request.SparkEnvVars = merge(b.request.SparkEnvVars, vars)
+	return b
+}
+
+// WithInitScript appends an init script. Scripts run in the order they were
+// added.
+func (b *SpecBuilder) WithInitScript(script models.ClustersInitScriptInfo) *SpecBuilder {
+	b.request.InitScripts = append(b.request.InitScripts, script)
+	return b
+}
+
+func (b *SpecBuilder) WithSshPublicKey(key string) *SpecBuilder {
+	b.request.SshPublicKeys = append(b.request.SshPublicKeys, key)
+	return b
+}
+
+func (b *SpecBuilder) WithClusterLogConf(conf *models.ClustersClusterLogConf) *SpecBuilder {
+	b.request.ClusterLogConf = conf
+	return b
+}
+
+func (b *SpecBuilder) WithAutotermination(minutes int32) *SpecBuilder {
+	b.request.AutoterminationMinutes = minutes
+	return b
+}
+
+func (b *SpecBuilder) WithElasticDisk() *SpecBuilder {
+	b.request.EnableElasticDisk = true
+	return b
+}
+
+func (b *SpecBuilder) WithDockerImage(image *models.ClustersDockerImage) *SpecBuilder {
+	b.request.DockerImage = image
+	return b
+}
+
+func (b *SpecBuilder) WithRuntimeEngine(engine models.ClustersRuntimeEngine) *SpecBuilder {
+	b.request.RuntimeEngine = &engine
+	return b
+}
+
+func (b *SpecBuilder) WithDataSecurityMode(mode models.ClustersDataSecurityMode) *SpecBuilder {
+	b.request.DataSecurityMode = &mode
+	return b
+}
+
+// WithSingleUser restricts the cluster to userName in SINGLE_USER mode.
+func (b *SpecBuilder) WithSingleUser(userName string) *SpecBuilder {
+	b.request.SingleUserName = userName
+	return b.WithDataSecurityMode(models.SINGLE_USER)
+}
+
+func (b *SpecBuilder) WithPolicy(policyId string, applyDefaultValues bool) *SpecBuilder {
+	b.request.PolicyId = policyId
+	b.request.ApplyPolicyDefaultValues = applyDefaultValues
+	return b
+}
+
+// Validate checks the spec and returns all violations as ValidationErrors, or
+// nil when the spec is valid.
+func (b *SpecBuilder) Validate() error {
+	r := &b.request
+	var errs ValidationErrors
+	check := func(err error) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if r.SparkVersion == "" {
+		check(fmt.Errorf("spark_version is required"))
+	}
+	if r.NodeTypeId == "" && !(r.PolicyId != "" && r.ApplyPolicyDefaultValues) {
+		check(fmt.Errorf("node_type_id is required"))
+	}
+	if r.NumWorkers < 0 {
+		check(fmt.Errorf("num_workers (%d) cannot be negative", r.NumWorkers))
+	}
+	if r.Autoscale != nil && r.Autoscale.MinWorkers < 0 {
+		check(fmt.Errorf("autoscale min_workers (%d) cannot be negative", r.Autoscale.MinWorkers))
+	}
+	check(validateSize(r.NumWorkers, r.Autoscale))
+	check(validateCloud(r.AwsAttributes, r.AzureAttributes, r.GcpAttributes))
+	if r.AwsAttributes != nil {
+		for _, err := range validateAws(r.AwsAttributes) {
+			check(err)
+		}
+	}
+	if r.AutoterminationMinutes != 0 && (r.AutoterminationMinutes < 10 || r.AutoterminationMinutes > 10000) {
+		check(fmt.Errorf("autotermination_minutes (%d) must be 0 or between 10 and 10000", r.AutoterminationMinutes))
+	}
+	if r.DataSecurityMode != nil && *r.DataSecurityMode == models.SINGLE_USER && r.SingleUserName == "" {
+		check(fmt.Errorf("single_user_name is required with data_security_mode SINGLE_USER"))
+	}
+	for i, script := range r.InitScripts {
+		if countSet(script.Dbfs != nil, script.S3 != nil, script.Workspace != nil, script.Volumes != nil) != 1 {
+			check(fmt.Errorf("init script %d must have exactly one destination", i))
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+// Build validates the spec and returns a create request. The request is a
+// deep copy, unaffected by later changes to the builder.
+func (b *SpecBuilder) Build() (*models.ClustersCreateRequest, error) {
+	err := b.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// Copying through JSON leaves no maps, slices or pointers shared with
+	// the builder.
+	data, err := json.Marshal(b.request)
+	if err != nil {
+		return nil, err
+	}
+
+	request := models.ClustersCreateRequest{}
+	err = json.Unmarshal(data, &request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &request, nil
+}
+
+// BuildEdit validates the spec and returns a request editing clusterId.
+func (b *SpecBuilder) BuildEdit(clusterId string) (*models.ClustersEditRequest, error) {
+	request, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return editRequest(clusterId, request), nil
+}
+
+func merge(dst map[string]string, src map[string]string) map[string]string {
+	if dst == nil {
+		dst = make(map[string]string, len(src))
+	}
+	for k, v := range src {
+		dst[k] = v
+	}
+
+	return dst
+}
+
+func countSet(set ...bool) int {
+	n := 0
+	for _, s := range set {
+		if s {
+			n++
+		}
+	}
+
+	return n
+}
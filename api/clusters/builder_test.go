@@ -0,0 +1,126 @@
+package clusters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/tcz001/databricks-sdk-go/models"
+)
+
+type BuilderTestSuite struct {
+	suite.Suite
+}
+
+func (s *BuilderTestSuite) TestBuildProducesCreateAndEditRequests() {
+	builder := NewSpecBuilder("10.4.x-scala2.12", "m5d.large").
+		WithName("etl").
+		WithAutoscale(1, 8).
+		WithSpot(1, 100).
+		WithTags(map[string]string{"team": "data"}).
+		WithTags(map[string]string{"env": "prod"}).
+		WithSparkConf(map[string]string{"spark.speculation": "true"}).
+		WithInitScript(models.ClustersInitScriptInfo{
+			Workspace: &models.ClustersWorkspaceStorageInfo{Destination: "/Shared/init.sh"},
+		}).
+		WithSingleUser("etl@example.com")
+
+	request, err := builder.Build()
+	s.Require().NoError(err)
+
+	s.Assert().Equal("etl", request.ClusterName)
+	s.Assert().Equal(&models.ClustersAutoScale{MinWorkers: 1, MaxWorkers: 8}, request.Autoscale)
+	s.Assert().Equal(models.SPOT_WITH_FALLBACK, *request.AwsAttributes.Availability)
+	s.Assert().Equal(map[string]string{"team": "data", "env": "prod"}, request.CustomTags)
+	s.Assert().Equal(models.SINGLE_USER, *request.DataSecurityMode)
+
+	edit, err := builder.BuildEdit("1234")
+	s.Require().NoError(err)
+	s.Assert().Equal("1234", edit.ClusterId)
+	s.Assert().Equal(request.InitScripts, edit.InitScripts)
+}
+
+func (s *BuilderTestSuite) TestBuildReturnsIndependentCopies() {
+	builder := NewSpecBuilder("10.4.x-scala2.12", "m5d.large").
+		WithNumWorkers(2).
+		WithSpot(1, 100).
+		WithTags(map[string]string{"a": "1"}).
+		WithSparkConf(map[string]string{"spark.speculation": "true"}).
+		WithSshPublicKey("ssh-rsa AAAA").
+		WithInitScript(models.ClustersInitScriptInfo{
+			Workspace: &models.ClustersWorkspaceStorageInfo{Destination: "/Shared/init.sh"},
+		})
+
+	request, err := builder.Build()
+	s.Require().NoError(err)
+
+	builder.
+		WithTags(map[string]string{"b": "2"}).
+		WithSparkConf(map[string]string{"spark.speculation": "false"}).
+		WithSshPublicKey("ssh-rsa BBBB").
+		WithInitScript(models.ClustersInitScriptInfo{
+			Workspace: &models.ClustersWorkspaceStorageInfo{Destination: "/Shared/other.sh"},
+		}).
+		WithSpot(2, 50)
+	request.InitScripts[0].Workspace.Destination = "/Shared/changed.sh"
+
+	s.Assert().Equal(map[string]string{"a": "1"}, request.CustomTags)
+	s.Assert().Equal(map[string]string{"spark.speculation": "true"}, request.SparkConf)
+	s.Assert().Equal([]string{"ssh-rsa AAAA"}, request.SshPublicKeys)
+	s.Assert().Len(request.InitScripts, 1)
+	s.Assert().Equal(int32(1), request.AwsAttributes.FirstOnDemand)
+
+	rebuilt, err := builder.Build()
+	s.Require().NoError(err)
+	s.Assert().Equal("/Shared/init.sh", rebuilt.InitScripts[0].Workspace.Destination)
+	s.Assert().Equal(int32(2), rebuilt.AwsAttributes.FirstOnDemand)
+}
+
+func (s *BuilderTestSuite) TestValidateReportsAllViolations() {
+	err := NewSpecBuilder("", "m5.large").
+		WithNumWorkers(2).
+		WithEbsVolumes(models.GENERAL_PURPOSE_SSD, 1, 0).
+		WithSpot(0, 20000).
+		WithGcpAttributes(&models.ClustersGcpAttributes{}).
+		WithAutotermination(5).
+		Validate()
+
+	errs, ok := err.(ValidationErrors)
+	s.Require().True(ok)
+	s.Assert().Equal([]string{
+		"spark_version is required",
+		"only one of aws_attributes, azure_attributes and gcp_attributes can be set, got aws_attributes and gcp_attributes",
+		"ebs_volume_count and ebs_volume_size must be set together",
+		"spot_bid_price_percent (20000) must be between 1 and 10000",
+		"autotermination_minutes (5) must be 0 or between 10 and 10000",
+	}, messages(errs))
+}
+
+func (s *BuilderTestSuite) TestValidateRequiresSpotBid() {
+	err := NewSpecBuilder("10.4.x-scala2.12", "m5.large").
+		WithNumWorkers(2).
+		WithSpot(1, 0).
+		Validate()
+
+	errs, ok := err.(ValidationErrors)
+	s.Require().True(ok)
+	s.Assert().Equal([]string{"spot_bid_price_percent (0) must be between 1 and 10000"}, messages(errs))
+	s.Assert().Equal([]error(errs), errs.Unwrap())
+
+	err = NewSpecBuilder("10.4.x-scala2.12", "m5.large").
+		WithNumWorkers(2).
+		WithAwsAttributes(&models.ClustersAwsAttributes{FirstOnDemand: 1}).
+		Validate()
+	s.Assert().NoError(err)
+}
+
+func messages(errs ValidationErrors) []string {
+	result := make([]string, len(errs))
+	for i, err := range errs {
+		result[i] = err.Error()
+	}
+	return result
+}
+
+func TestBuilderSuite(t *testing.T) {
+	suite.Run(t, new(BuilderTestSuite))
+}
@@ -0,0 +1,74 @@
+package clusters
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// FieldDiff describes a field whose value on the cluster differs from the
+// spec. Field is a dotted path such as "aws_attributes.availability".
+type FieldDiff struct {
+	Field   string      `json:"field"`
+	Desired interface{} `json:"desired"`
+	Actual  interface{} `json:"actual"`
+}
+
+// writeOnlyFields are accepted by the service but never returned.
+var writeOnlyFields = map[string]bool{
+	"docker_image.basic_auth.password": true,
+}
+
+// DiffValue compares the JSON representations of a desired and an actual
+// field value, as decoded into interface{}. Nested objects only compare the
+// keys set in want, since the service fills in defaults for the others, while
+// free-form maps such as spark_conf are compared as a whole.
+func DiffValue(field string, want interface{}, got interface{}) []FieldDiff {
+	if writeOnlyFields[field] {
+		return nil
+	}
+
+	wantObj, wantIsObj := want.(map[string]interface{})
+	gotObj, gotIsObj := got.(map[string]interface{})
+	if wantIsObj && gotIsObj && !isMapField(field) {
+		var diffs []FieldDiff
+		for k, v := range wantObj {
+			diffs = append(diffs, DiffValue(field+"."+k, v, gotObj[k])...)
+		}
+		return diffs
+	}
+
+	if reflect.DeepEqual(want, got) {
+		return nil
+	}
+
+	return []FieldDiff{{Field: field, Desired: want, Actual: got}}
+}
+
+func isMapField(field string) bool {
+	return field == "spark_conf" || field == "spark_env_vars" || field == "custom_tags"
+}
+
+// differs compares two model values with DiffValue.
+func differs(field string, want interface{}, got interface{}) bool {
+	wantValue, err := decode(want)
+	if err != nil {
+		return !reflect.DeepEqual(want, got)
+	}
+	gotValue, err := decode(got)
+	if err != nil {
+		return !reflect.DeepEqual(want, got)
+	}
+
+	return len(DiffValue(field, wantValue, gotValue)) > 0
+}
+
+func decode(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var value interface{}
+	err = json.Unmarshal(data, &value)
+	return value, err
+}
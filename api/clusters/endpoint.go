@@ -2,10 +2,8 @@ package clusters
 
 import (
 	"encoding/json"
-	"fmt"
 	"github.com/tcz001/databricks-sdk-go/client"
 	"github.com/tcz001/databricks-sdk-go/models"
-	"time"
 )
 
 type Endpoint struct {
@@ -13,6 +11,15 @@ type Endpoint struct {
 }
 
 func (c *Endpoint) Create(request *models.ClustersCreateRequest) (*models.ClustersCreateResponse, error) {
+	err := validateSize(request.NumWorkers, request.Autoscale)
+	if err != nil {
+		return nil, err
+	}
+	err = validateCloud(request.AwsAttributes, request.AzureAttributes, request.GcpAttributes)
+	if err != nil {
+		return nil, err
+	}
+
 	bytes, err := c.Client.Query("POST", "clusters/create", request)
 	if err != nil {
 		return nil, err
@@ -27,7 +34,7 @@ func (c *Endpoint) Create(request *models.ClustersCreateRequest) (*models.Cluste
 	return &resp, nil
 }
 
-func (c *Endpoint) CreateSync(request *models.ClustersCreateRequest) (
+func (c *Endpoint) CreateSync(request *models.ClustersCreateRequest, opts ...WaitOptions) (
 	resp *models.ClustersCreateResponse,
 	err error,
 ) {
@@ -42,17 +49,26 @@ func (c *Endpoint) CreateSync(request *models.ClustersCreateRequest) (
 
 	err = c.executeSync(opFunc, models.RUNNING, []models.ClustersClusterState{
 		models.PENDING,
-	})
+	}, opts)
 
 	return
 }
 
 func (c *Endpoint) Edit(request *models.ClustersEditRequest) error {
-	_, err := c.Client.Query("POST", "clusters/edit", request)
+	err := validateSize(request.NumWorkers, request.Autoscale)
+	if err != nil {
+		return err
+	}
+	err = validateCloud(request.AwsAttributes, request.AzureAttributes, request.GcpAttributes)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Client.Query("POST", "clusters/edit", request)
 	return err
 }
 
-func (c *Endpoint) EditSync(request *models.ClustersEditRequest) error {
+func (c *Endpoint) EditSync(request *models.ClustersEditRequest, opts ...WaitOptions) error {
 	opFunc := func() (*string, error) { return &request.ClusterId, c.Edit(request) }
 
 	state, err := c.getState(request.ClusterId)
@@ -60,13 +76,15 @@ func (c *Endpoint) EditSync(request *models.ClustersEditRequest) error {
 		return err
 	}
 
+	// Editing a terminated cluster does not start it, so there is nothing to
+	// wait for.
 	if *state == models.TERMINATED {
-		return nil
+		return c.Edit(request)
 	}
 
 	return c.executeSync(opFunc, models.RUNNING, []models.ClustersClusterState{
 		models.RESTARTING,
-	})
+	}, opts)
 }
 
 func (c *Endpoint) Start(request *models.ClustersStartRequest) error {
@@ -74,9 +92,9 @@ func (c *Endpoint) Start(request *models.ClustersStartRequest) error {
 	return err
 }
 
-func (c *Endpoint) StartSync(request *models.ClustersStartRequest) error {
+func (c *Endpoint) StartSync(request *models.ClustersStartRequest, opts ...WaitOptions) error {
 	opFunc := func() (*string, error) { return &request.ClusterId, c.Start(request) }
-	return c.executeSync(opFunc, models.RUNNING, []models.ClustersClusterState{models.PENDING})
+	return c.executeSync(opFunc, models.RUNNING, []models.ClustersClusterState{models.PENDING}, opts)
 }
 
 func (c *Endpoint) Restart(request *models.ClustersRestartRequest) error {
@@ -84,9 +102,9 @@ func (c *Endpoint) Restart(request *models.ClustersRestartRequest) error {
 	return err
 }
 
-func (c *Endpoint) RestartSync(request *models.ClustersRestartRequest) error {
+func (c *Endpoint) RestartSync(request *models.ClustersRestartRequest, opts ...WaitOptions) error {
 	opFunc := func() (*string, error) { return &request.ClusterId, c.Restart(request) }
-	return c.executeSync(opFunc, models.RUNNING, []models.ClustersClusterState{models.RESTARTING})
+	return c.executeSync(opFunc, models.RUNNING, []models.ClustersClusterState{models.RESTARTING}, opts)
 }
 
 func (c *Endpoint) Delete(request *models.ClustersDeleteRequest) error {
@@ -94,14 +112,14 @@ func (c *Endpoint) Delete(request *models.ClustersDeleteRequest) error {
 	return err
 }
 
-func (c *Endpoint) DeleteSync(request *models.ClustersDeleteRequest) error {
+func (c *Endpoint) DeleteSync(request *models.ClustersDeleteRequest, opts ...WaitOptions) error {
 	opFunc := func() (*string, error) { return &request.ClusterId, c.Delete(request) }
 	return c.executeSync(opFunc, models.TERMINATED, []models.ClustersClusterState{
 		models.PENDING,
 		models.RESTARTING,
 		models.RESIZING,
 		models.TERMINATING,
-	})
+	}, opts)
 }
 
 func (c *Endpoint) PermanentDelete(request *models.ClustersPermanentDeleteRequest) error {
@@ -139,41 +157,65 @@ func (c *Endpoint) List() (*models.ClustersListResponse, error) {
 	return &resp, nil
 }
 
-func (c *Endpoint) executeSync(
-	opFunc func() (*string, error),
-	state models.ClustersClusterState,
-	validStates []models.ClustersClusterState,
-) error {
-	clusterId, err := opFunc()
+func (c *Endpoint) ListNodeTypes() (*models.ClustersListNodeTypesResponse, error) {
+	bytes, err := c.Client.Query("GET", "clusters/list-node-types", nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	validStatesMap := make(map[models.ClustersClusterState]bool, len(validStates))
-	for _, v := range validStates {
-		validStatesMap[v] = true
+	resp := models.ClustersListNodeTypesResponse{}
+	err = json.Unmarshal(bytes, &resp)
+	if err != nil {
+		return nil, err
 	}
 
-	endTime := time.Now().Add(30 * time.Minute)
+	return &resp, nil
+}
 
-	for time.Now().Before(endTime) {
-		currState, err := c.getState(*clusterId)
-		if err != nil {
-			return err
-		}
+func (c *Endpoint) SparkVersions() (*models.ClustersSparkVersionsResponse, error) {
+	bytes, err := c.Client.Query("GET", "clusters/spark-versions", nil)
+	if err != nil {
+		return nil, err
+	}
 
-		if *currState == state {
-			return nil
-		}
+	resp := models.ClustersSparkVersionsResponse{}
+	err = json.Unmarshal(bytes, &resp)
+	if err != nil {
+		return nil, err
+	}
 
-		if _, ok := validStatesMap[*currState]; !ok {
-			return fmt.Errorf("unexpected state (%s) for cluster %s", *currState, *clusterId)
-		}
+	return &resp, nil
+}
+
+// ListZones returns the availability zones clusters can be created in. It is
+// only available on AWS.
+func (c *Endpoint) ListZones() (*models.ClustersListZonesResponse, error) {
+	bytes, err := c.Client.Query("GET", "clusters/list-zones", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := models.ClustersListZonesResponse{}
+	err = json.Unmarshal(bytes, &resp)
+	if err != nil {
+		return nil, err
+	}
 
-		time.Sleep(10 * time.Second)
+	return &resp, nil
+}
+
+func (c *Endpoint) executeSync(
+	opFunc func() (*string, error),
+	state models.ClustersClusterState,
+	validStates []models.ClustersClusterState,
+	opts []WaitOptions,
+) error {
+	clusterId, err := opFunc()
+	if err != nil {
+		return err
 	}
 
-	return fmt.Errorf("timeout when waiting for cluster %s to have state %s", *clusterId, state)
+	return c.WaitForState(*clusterId, state, validStates, opts...)
 }
 
 func (c *Endpoint) getState(clusterId string) (*models.ClustersClusterState, error) {
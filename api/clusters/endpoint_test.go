@@ -0,0 +1,363 @@
+package clusters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/tcz001/databricks-sdk-go/client"
+	"github.com/tcz001/databricks-sdk-go/models"
+	"gopkg.in/h2non/gock.v1"
+)
+
+type EndpointTestSuite struct {
+	suite.Suite
+	endpoint Endpoint
+}
+
+func (s *EndpointTestSuite) SetupTest() {
+	domain := "server.com"
+	token := "token"
+	cl, err := client.NewClient(client.Options{Domain: &domain, Token: &token, RateLimitPerSecond: 100})
+	s.Require().NoError(err)
+
+	s.endpoint = Endpoint{Client: cl}
+}
+
+func (s *EndpointTestSuite) TearDownTest() {
+	gock.Off()
+}
+
+func (s *EndpointTestSuite) TestStartSyncReturnsClusterStateError() {
+	gock.New("https://server.com").
+		Post("^/api/2.0/clusters/start$").
+		Reply(200)
+	gock.New("https://server.com").
+		Get("^/api/2.0/clusters/get$").
+		Reply(200).
+		JSON(map[string]interface{}{
+			"cluster_id":    "1234",
+			"state":         "TERMINATED",
+			"state_message": "Cannot launch the cluster",
+			"termination_reason": map[string]interface{}{
+				"code":       "CLOUD_PROVIDER_LAUNCH_FAILURE",
+				"parameters": map[string]string{"aws_error_code": "InsufficientInstanceCapacity"},
+			},
+		})
+	gock.New("https://server.com").
+		Post("^/api/2.0/clusters/events$").
+		BodyString(`"order":"DESC"`).
+		Reply(200).
+		JSON(map[string]interface{}{
+			"events": []map[string]interface{}{
+				{"cluster_id": "1234", "timestamp": 2, "type": "TERMINATING"},
+				{"cluster_id": "1234", "timestamp": 1, "type": "CREATING"},
+			},
+		})
+
+	err := s.endpoint.StartSync(&models.ClustersStartRequest{ClusterId: "1234"})
+
+	var stateErr *ClusterStateError
+	s.Require().True(errors.As(err, &stateErr))
+	s.Assert().Equal("1234", stateErr.ClusterId)
+	s.Assert().Equal(models.TERMINATED, stateErr.State)
+	s.Assert().Equal(models.CLOUD_PROVIDER_LAUNCH_FAILURE, stateErr.TerminationCode)
+	s.Assert().Equal("InsufficientInstanceCapacity", stateErr.TerminationParameters["aws_error_code"])
+	s.Require().Len(stateErr.Events, 2)
+	s.Assert().Equal(models.TERMINATING_, *stateErr.Events[0].Type_)
+	s.Assert().Equal(
+		"unexpected state (TERMINATED) for cluster 1234: Cannot launch the cluster "+
+			"(CLOUD_PROVIDER_LAUNCH_FAILURE, aws_error_code=InsufficientInstanceCapacity)",
+		err.Error(),
+	)
+	s.Assert().True(gock.IsDone())
+}
+
+func (s *EndpointTestSuite) TestWaitForStateReportsStateChanges() {
+	for _, state := range []string{"PENDING", "PENDING", "RUNNING"} {
+		gock.New("https://server.com").
+			Get("^/api/2.0/clusters/get$").
+			Reply(200).
+			JSON(map[string]interface{}{"cluster_id": "1234", "state": state})
+	}
+
+	var changes []string
+	err := s.endpoint.WaitForState("1234", models.RUNNING, []models.ClustersClusterState{models.PENDING}, WaitOptions{
+		InitialPollInterval: time.Millisecond,
+		MaxPollInterval:     2 * time.Millisecond,
+		OnStateChange: func(clusterId string, from models.ClustersClusterState, to models.ClustersClusterState) {
+			changes = append(changes, fmt.Sprintf("%s:%s->%s", clusterId, from, to))
+		},
+	})
+	s.Require().NoError(err)
+
+	s.Assert().Equal([]string{"1234:->PENDING", "1234:PENDING->RUNNING"}, changes)
+	s.Assert().True(gock.IsDone())
+}
+
+func (s *EndpointTestSuite) TestWaitForStateHonorsContext() {
+	gock.New("https://server.com").
+		Get("^/api/2.0/clusters/get$").
+		Persist().
+		Reply(200).
+		JSON(map[string]interface{}{"cluster_id": "1234", "state": "PENDING"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := s.endpoint.WaitForState("1234", models.RUNNING, []models.ClustersClusterState{models.PENDING}, WaitOptions{
+		Context:             ctx,
+		InitialPollInterval: time.Millisecond,
+	})
+	s.Assert().Equal(context.DeadlineExceeded, err)
+}
+
+func (s *EndpointTestSuite) TestResizeSyncWaitsForExecutors() {
+	gock.New("https://server.com").
+		Post("^/api/2.0/clusters/resize$").
+		BodyString(`"num_workers":2`).
+		Reply(200)
+	for _, cluster := range []map[string]interface{}{
+		{"state": "RUNNING", "executors": []map[string]string{{"node_id": "a"}}},
+		{"state": "RESIZING", "executors": []map[string]string{{"node_id": "a"}}},
+		{"state": "RUNNING", "executors": []map[string]string{{"node_id": "a"}, {"node_id": "b"}}},
+	} {
+		gock.New("https://server.com").
+			Get("^/api/2.0/clusters/get$").
+			Reply(200).
+			JSON(cluster)
+	}
+
+	err := s.endpoint.ResizeSync(&models.ClustersResizeRequest{ClusterId: "1234", NumWorkers: 2}, WaitOptions{
+		InitialPollInterval: time.Millisecond,
+	})
+	s.Require().NoError(err)
+	s.Assert().True(gock.IsDone())
+}
+
+func (s *EndpointTestSuite) TestResizeRejectsNumWorkersWithAutoscale() {
+	err := s.endpoint.Resize(&models.ClustersResizeRequest{
+		ClusterId:  "1234",
+		NumWorkers: 2,
+		Autoscale:  &models.ClustersAutoScale{MinWorkers: 1, MaxWorkers: 4},
+	})
+	s.Assert().EqualError(err, "num_workers and autoscale are mutually exclusive")
+
+	_, err = s.endpoint.Create(&models.ClustersCreateRequest{
+		Autoscale: &models.ClustersAutoScale{MinWorkers: 4, MaxWorkers: 1},
+	})
+	s.Assert().Error(err)
+}
+
+func (s *EndpointTestSuite) TestCreateRejectsMultipleClouds() {
+	_, err := s.endpoint.Create(&models.ClustersCreateRequest{
+		AwsAttributes: &models.ClustersAwsAttributes{},
+		GcpAttributes: &models.ClustersGcpAttributes{LocalSsdCount: 1},
+	})
+	s.Assert().EqualError(err, "only one of aws_attributes, azure_attributes and gcp_attributes can be set, got aws_attributes and gcp_attributes")
+}
+
+func (s *EndpointTestSuite) TestSafePermanentDeleteRefusesProtectedClusters() {
+	gock.New("https://server.com").
+		Get("^/api/2.0/clusters/get$").
+		Reply(200).
+		JSON(map[string]interface{}{"cluster_id": "1234", "pinned_by_user_name": "admin@example.com"})
+	gock.New("https://server.com").
+		Get("^/api/2.0/clusters/get$").
+		Reply(200).
+		JSON(map[string]interface{}{"cluster_id": "1234", "custom_tags": map[string]string{"protected": "true"}})
+
+	for i := 0; i < 2; i++ {
+		err := s.endpoint.SafePermanentDelete(&models.ClustersPermanentDeleteRequest{ClusterId: "1234"}, SafeDeleteOptions{})
+		s.Assert().True(errors.Is(err, ErrClusterProtected))
+	}
+
+	gock.New("https://server.com").
+		Post("^/api/2.0/clusters/permanent-delete$").
+		Reply(200)
+
+	err := s.endpoint.SafePermanentDelete(&models.ClustersPermanentDeleteRequest{ClusterId: "1234"}, SafeDeleteOptions{Force: true})
+	s.Require().NoError(err)
+	s.Assert().True(gock.IsDone())
+}
+
+func (s *EndpointTestSuite) TestEnsureClusterEditsAndStartsTerminatedCluster() {
+	gock.New("https://server.com").
+		Get("^/api/2.0/clusters/list$").
+		Reply(200).
+		JSON(map[string]interface{}{
+			"clusters": []map[string]interface{}{
+				{"cluster_id": "other", "cluster_name": "adhoc", "state": "RUNNING"},
+				{
+					"cluster_id":    "1234",
+					"cluster_name":  "etl",
+					"state":         "TERMINATED",
+					"spark_version": "9.1.x-scala2.12",
+					"node_type_id":  "m5.large",
+					"num_workers":   2,
+				},
+			},
+		})
+	gock.New("https://server.com").
+		Get("^/api/2.0/clusters/get$").
+		Reply(200).
+		JSON(map[string]interface{}{"cluster_id": "1234", "state": "TERMINATED"})
+	gock.New("https://server.com").
+		Post("^/api/2.0/clusters/edit$").
+		BodyString(`"spark_version":"10.4.x-scala2.12"`).
+		Reply(200)
+	gock.New("https://server.com").
+		Post("^/api/2.0/clusters/start$").
+		Reply(200)
+	gock.New("https://server.com").
+		Get("^/api/2.0/clusters/get$").
+		Reply(200).
+		JSON(map[string]interface{}{"cluster_id": "1234", "state": "RUNNING"})
+
+	result, err := s.endpoint.EnsureCluster(&models.ClustersCreateRequest{
+		ClusterName:  "etl",
+		SparkVersion: "10.4.x-scala2.12",
+		NodeTypeId:   "m5.large",
+		NumWorkers:   2,
+	}, EnsureOptions{})
+	s.Require().NoError(err)
+
+	s.Assert().Equal(&EnsureResult{ClusterId: "1234", Edited: []string{"spark_version"}, Started: true}, result)
+	s.Assert().True(gock.IsDone())
+}
+
+func (s *EndpointTestSuite) TestEnsureClusterIgnoresServerDefaults() {
+	gock.New("https://server.com").
+		Get("^/api/2.0/clusters/list$").
+		Reply(200).
+		JSON(map[string]interface{}{
+			"clusters": []map[string]interface{}{
+				{
+					"cluster_id":    "1234",
+					"cluster_name":  "etl",
+					"state":         "RUNNING",
+					"spark_version": "10.4.x-scala2.12",
+					"node_type_id":  "m5.large",
+					"num_workers":   2,
+					"aws_attributes": map[string]interface{}{
+						"availability":           "SPOT_WITH_FALLBACK",
+						"zone_id":                "us-west-2a",
+						"first_on_demand":        1,
+						"spot_bid_price_percent": 100,
+					},
+					"docker_image": map[string]interface{}{
+						"url":        "databricksruntime/standard:latest",
+						"basic_auth": map[string]interface{}{"username": "user"},
+					},
+					"cluster_log_conf": map[string]interface{}{
+						"s3": map[string]interface{}{"destination": "s3://logs", "region": "us-west-2"},
+					},
+				},
+			},
+		})
+
+	availability := models.SPOT_WITH_FALLBACK
+	result, err := s.endpoint.EnsureCluster(&models.ClustersCreateRequest{
+		ClusterName:   "etl",
+		SparkVersion:  "10.4.x-scala2.12",
+		NodeTypeId:    "m5.large",
+		NumWorkers:    2,
+		AwsAttributes: &models.ClustersAwsAttributes{Availability: &availability},
+		DockerImage: &models.ClustersDockerImage{
+			Url:       "databricksruntime/standard:latest",
+			BasicAuth: &models.ClustersDockerBasicAuth{Username: "user", Password: "secret"},
+		},
+		ClusterLogConf: &models.ClustersClusterLogConf{S3: &models.ClustersS3StorageInfo{Destination: "s3://logs"}},
+	}, EnsureOptions{})
+	s.Require().NoError(err)
+
+	s.Assert().Equal(&EnsureResult{ClusterId: "1234"}, result)
+	s.Assert().True(gock.IsDone())
+	s.Assert().False(gock.HasUnmatchedRequest())
+}
+
+func (s *EndpointTestSuite) TestDiffSpecComparesNestedKeys() {
+	onDemand := models.ON_DEMAND
+	spot := models.SPOT_WITH_FALLBACK
+	spec := &models.ClustersCreateRequest{
+		AwsAttributes: &models.ClustersAwsAttributes{Availability: &onDemand},
+		SparkConf:     map[string]string{"a": "1"},
+		CustomTags:    map[string]string{"team": "data"},
+	}
+	cluster := &models.ClustersClusterInfo{
+		AwsAttributes: &models.ClustersAwsAttributes{Availability: &spot, ZoneId: "us-west-2a"},
+		SparkConf:     map[string]string{"a": "1", "b": "2"},
+		CustomTags:    map[string]string{"team": "data", "protected": "true"},
+	}
+
+	s.Assert().Equal([]string{"spark_conf", "aws_attributes"}, DiffSpec(spec, cluster))
+}
+
+func (s *EndpointTestSuite) TestEnsureClusterKeepsTagsAddedOutsideSpec() {
+	gock.New("https://server.com").
+		Get("^/api/2.0/clusters/list$").
+		Reply(200).
+		JSON(map[string]interface{}{
+			"clusters": []map[string]interface{}{
+				{
+					"cluster_id":    "1234",
+					"cluster_name":  "etl",
+					"state":         "RUNNING",
+					"spark_version": "9.1.x-scala2.12",
+					"num_workers":   2,
+					"custom_tags":   map[string]string{"team": "data", "protected": "true", "Vendor": "Databricks"},
+					"default_tags":  map[string]string{"Vendor": "Databricks"},
+				},
+			},
+		})
+	gock.New("https://server.com").
+		Get("^/api/2.0/clusters/get$").
+		Reply(200).
+		JSON(map[string]interface{}{"cluster_id": "1234", "state": "RUNNING"})
+	gock.New("https://server.com").
+		Post("^/api/2.0/clusters/edit$").
+		BodyString(`"custom_tags":{"protected":"true","team":"data"}`).
+		Reply(200)
+	gock.New("https://server.com").
+		Get("^/api/2.0/clusters/get$").
+		Reply(200).
+		JSON(map[string]interface{}{"cluster_id": "1234", "state": "RUNNING"})
+
+	result, err := s.endpoint.EnsureCluster(&models.ClustersCreateRequest{
+		ClusterName:  "etl",
+		SparkVersion: "10.4.x-scala2.12",
+		NumWorkers:   2,
+		CustomTags:   map[string]string{"team": "data"},
+	}, EnsureOptions{})
+	s.Require().NoError(err)
+
+	s.Assert().Equal([]string{"spark_version"}, result.Edited)
+	s.Assert().True(gock.IsDone())
+	s.Assert().False(gock.HasUnmatchedRequest())
+}
+
+func (s *EndpointTestSuite) TestEnsureClusterReportsAmbiguousMatchTag() {
+	gock.New("https://server.com").
+		Get("^/api/2.0/clusters/list$").
+		Reply(200).
+		JSON(map[string]interface{}{
+			"clusters": []map[string]interface{}{
+				{"cluster_id": "1", "custom_tags": map[string]string{"app": "etl"}},
+				{"cluster_id": "2", "custom_tags": map[string]string{"app": "etl"}},
+			},
+		})
+
+	_, err := s.endpoint.EnsureCluster(&models.ClustersCreateRequest{
+		ClusterName: "etl-v2",
+		CustomTags:  map[string]string{"app": "etl"},
+	}, EnsureOptions{MatchTag: "app"})
+
+	s.Assert().EqualError(err, `found 2 clusters tagged app="etl"`)
+}
+
+func TestEndpointSuite(t *testing.T) {
+	suite.Run(t, new(EndpointTestSuite))
+}
@@ -0,0 +1,241 @@
+package clusters
+
+import (
+	"fmt"
+
+	"github.com/tcz001/databricks-sdk-go/models"
+)
+
+type EnsureOptions struct {
+	// MatchTag identifies the cluster by the value spec.CustomTags[MatchTag]
+	// instead of by spec.ClusterName.
+	MatchTag string
+
+	// Wait configures how long to wait for the cluster to be created,
+	// restarted or started.
+	Wait WaitOptions
+}
+
+type EnsureResult struct {
+	ClusterId string
+
+	Created bool
+
+	// Edited lists the spec fields that differed from the existing cluster
+	// and were updated.
+	Edited []string
+
+	Started bool
+}
+
+// EnsureCluster makes sure a cluster matching spec exists and is running. The
+// cluster is looked up by name, or by the MatchTag custom tag; it is created
+// when missing, edited when its configuration differs from spec and started
+// when terminated.
+func (c *Endpoint) EnsureCluster(spec *models.ClustersCreateRequest, opts EnsureOptions) (*EnsureResult, error) {
+	cluster, err := c.find(spec, opts.MatchTag)
+	if err != nil {
+		return nil, err
+	}
+
+	if cluster == nil {
+		resp, err := c.CreateSync(spec, opts.Wait)
+		if err != nil {
+			return nil, err
+		}
+		return &EnsureResult{ClusterId: resp.ClusterId, Created: true}, nil
+	}
+
+	result := EnsureResult{ClusterId: cluster.ClusterId}
+
+	state, err := c.settle(cluster, opts.Wait)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Edited = DiffSpec(spec, cluster)
+	if len(result.Edited) > 0 {
+		edit := editRequest(cluster.ClusterId, spec)
+		edit.CustomTags = keepTags(cluster, spec.CustomTags)
+		err := c.EditSync(edit, opts.Wait)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if state == models.TERMINATED {
+		err := c.StartSync(&models.ClustersStartRequest{ClusterId: cluster.ClusterId}, opts.Wait)
+		if err != nil {
+			return nil, err
+		}
+		result.Started = true
+	}
+
+	return &result, nil
+}
+
+func (c *Endpoint) find(spec *models.ClustersCreateRequest, matchTag string) (*models.ClustersClusterInfo, error) {
+	resp, err := c.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []models.ClustersClusterInfo
+	for _, cluster := range resp.Clusters {
+		if matchTag != "" {
+			value, ok := cluster.CustomTags[matchTag]
+			if ok && value == spec.CustomTags[matchTag] {
+				matches = append(matches, cluster)
+			}
+		} else if cluster.ClusterName == spec.ClusterName {
+			matches = append(matches, cluster)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &matches[0], nil
+	}
+
+	if matchTag != "" {
+		return nil, fmt.Errorf("found %d clusters tagged %s=%q", len(matches), matchTag, spec.CustomTags[matchTag])
+	}
+
+	return nil, fmt.Errorf("found %d clusters matching %q", len(matches), spec.ClusterName)
+}
+
+// settle waits for a cluster in a transitional state to become RUNNING or
+// TERMINATED and returns that state.
+func (c *Endpoint) settle(cluster *models.ClustersClusterInfo, opts WaitOptions) (models.ClustersClusterState, error) {
+	if cluster.State == nil {
+		return "", fmt.Errorf("unknown state for cluster %s", cluster.ClusterId)
+	}
+
+	switch *cluster.State {
+	case models.RUNNING, models.TERMINATED:
+		return *cluster.State, nil
+	case models.PENDING, models.RESTARTING, models.RESIZING:
+		err := c.WaitForState(cluster.ClusterId, models.RUNNING, []models.ClustersClusterState{
+			models.PENDING,
+			models.RESTARTING,
+			models.RESIZING,
+		}, opts)
+		return models.RUNNING, err
+	case models.TERMINATING:
+		err := c.WaitForState(cluster.ClusterId, models.TERMINATED, []models.ClustersClusterState{
+			models.TERMINATING,
+		}, opts)
+		return models.TERMINATED, err
+	}
+
+	return "", fmt.Errorf("unexpected state (%s) for cluster %s", *cluster.State, cluster.ClusterId)
+}
+
+// DiffSpec returns the JSON names of the fields of spec that differ from the
+// cluster configuration. Fields left empty in spec are assumed to use the
+// server default and are not compared, except for num_workers when spec does
+// not autoscale. Nested objects are compared with DiffValue, so that server
+// defaults such as aws_attributes.zone_id are not reported, and custom tags
+// only on the keys spec sets, so that tags added by others such as
+// DefaultProtectionTag are not reported either.
+func DiffSpec(spec *models.ClustersCreateRequest, cluster *models.ClustersClusterInfo) []string {
+	var fields []string
+	diff := func(name string, set bool, want interface{}, got interface{}) {
+		if set && differs(name, want, got) {
+			fields = append(fields, name)
+		}
+	}
+
+	if spec.Autoscale != nil {
+		diff("autoscale", true, spec.Autoscale, cluster.Autoscale)
+	} else {
+		diff("num_workers", true, spec.NumWorkers, cluster.NumWorkers)
+		diff("autoscale", cluster.Autoscale != nil, spec.Autoscale, cluster.Autoscale)
+	}
+	diff("cluster_name", spec.ClusterName != "", spec.ClusterName, cluster.ClusterName)
+	diff("spark_version", spec.SparkVersion != "", spec.SparkVersion, cluster.SparkVersion)
+	diff("spark_conf", len(spec.SparkConf) > 0, spec.SparkConf, cluster.SparkConf)
+	diff("aws_attributes", spec.AwsAttributes != nil, spec.AwsAttributes, cluster.AwsAttributes)
+	diff("azure_attributes", spec.AzureAttributes != nil, spec.AzureAttributes, cluster.AzureAttributes)
+	diff("gcp_attributes", spec.GcpAttributes != nil, spec.GcpAttributes, cluster.GcpAttributes)
+	diff("node_type_id", spec.NodeTypeId != "", spec.NodeTypeId, cluster.NodeTypeId)
+	diff("driver_node_type_id", spec.DriverNodeTypeId != "", spec.DriverNodeTypeId, cluster.DriverNodeTypeId)
+	diff("ssh_public_keys", len(spec.SshPublicKeys) > 0, spec.SshPublicKeys, cluster.SshPublicKeys)
+	diff("custom_tags", len(spec.CustomTags) > 0, spec.CustomTags, specTags(spec.CustomTags, cluster.CustomTags))
+	diff("cluster_log_conf", spec.ClusterLogConf != nil, spec.ClusterLogConf, cluster.ClusterLogConf)
+	diff("spark_env_vars", len(spec.SparkEnvVars) > 0, spec.SparkEnvVars, cluster.SparkEnvVars)
+	diff("autotermination_minutes", spec.AutoterminationMinutes != 0, spec.AutoterminationMinutes, cluster.AutoterminationMinutes)
+	diff("enable_elastic_disk", spec.EnableElasticDisk, spec.EnableElasticDisk, cluster.EnableElasticDisk)
+	diff("init_scripts", len(spec.InitScripts) > 0, spec.InitScripts, cluster.InitScripts)
+	diff("docker_image", spec.DockerImage != nil, spec.DockerImage, cluster.DockerImage)
+	diff("runtime_engine", spec.RuntimeEngine != nil, spec.RuntimeEngine, cluster.RuntimeEngine)
+	diff("data_security_mode", spec.DataSecurityMode != nil, spec.DataSecurityMode, cluster.DataSecurityMode)
+	diff("single_user_name", spec.SingleUserName != "", spec.SingleUserName, cluster.SingleUserName)
+	diff("policy_id", spec.PolicyId != "", spec.PolicyId, cluster.PolicyId)
+
+	return fields
+}
+
+// specTags returns the tags of the cluster whose keys are set in spec.
+func specTags(spec map[string]string, tags map[string]string) map[string]string {
+	result := make(map[string]string, len(spec))
+	for k := range spec {
+		if v, ok := tags[k]; ok {
+			result[k] = v
+		}
+	}
+
+	return result
+}
+
+// keepTags returns the custom tags of the cluster updated with the tags of
+// spec. Editing a cluster replaces all of its custom tags, which would drop
+// tags added outside of spec. Default tags are left to the service.
+func keepTags(cluster *models.ClustersClusterInfo, spec map[string]string) map[string]string {
+	tags := map[string]string{}
+	for k, v := range cluster.CustomTags {
+		if d, ok := cluster.DefaultTags[k]; ok && d == v {
+			continue
+		}
+		tags[k] = v
+	}
+	for k, v := range spec {
+		tags[k] = v
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	return tags
+}
+
+func editRequest(clusterId string, spec *models.ClustersCreateRequest) *models.ClustersEditRequest {
+	return &models.ClustersEditRequest{
+		ClusterId:                clusterId,
+		NumWorkers:               spec.NumWorkers,
+		Autoscale:                spec.Autoscale,
+		ClusterName:              spec.ClusterName,
+		SparkVersion:             spec.SparkVersion,
+		SparkConf:                spec.SparkConf,
+		AwsAttributes:            spec.AwsAttributes,
+		AzureAttributes:          spec.AzureAttributes,
+		GcpAttributes:            spec.GcpAttributes,
+		NodeTypeId:               spec.NodeTypeId,
+		DriverNodeTypeId:         spec.DriverNodeTypeId,
+		SshPublicKeys:            spec.SshPublicKeys,
+		CustomTags:               spec.CustomTags,
+		ClusterLogConf:           spec.ClusterLogConf,
+		SparkEnvVars:             spec.SparkEnvVars,
+		AutoterminationMinutes:   spec.AutoterminationMinutes,
+		EnableElasticDisk:        spec.EnableElasticDisk,
+		InitScripts:              spec.InitScripts,
+		DockerImage:              spec.DockerImage,
+		RuntimeEngine:            spec.RuntimeEngine,
+		DataSecurityMode:         spec.DataSecurityMode,
+		SingleUserName:           spec.SingleUserName,
+		PolicyId:                 spec.PolicyId,
+		ApplyPolicyDefaultValues: spec.ApplyPolicyDefaultValues,
+	}
+}
@@ -0,0 +1,81 @@
+package clusters
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/tcz001/databricks-sdk-go/models"
+)
+
+// ErrorEventsLimit is the number of recent events attached to a
+// ClusterStateError.
+const ErrorEventsLimit = 5
+
+// ClusterStateError is returned by the Sync methods when a cluster reaches a
+// state other than the one waited for, e.g. TERMINATED after a failed launch.
+type ClusterStateError struct {
+	ClusterId    string
+	State        models.ClustersClusterState
+	StateMessage string
+
+	// TerminationCode and TerminationParameters are taken from the
+	// termination reason reported by the cluster, if any.
+	TerminationCode       models.ClustersTerminationCode
+	TerminationParameters map[string]string
+
+	// Events holds the most recent cluster events, newest first.
+	Events []models.ClustersClusterEvent
+}
+
+func (e *ClusterStateError) Error() string {
+	msg := fmt.Sprintf("unexpected state (%s) for cluster %s", e.State, e.ClusterId)
+	if e.StateMessage != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.StateMessage)
+	}
+	if e.TerminationCode != "" {
+		reason := string(e.TerminationCode)
+		params := make([]string, 0, len(e.TerminationParameters))
+		for k, v := range e.TerminationParameters {
+			params = append(params, fmt.Sprintf("%s=%s", k, v))
+		}
+		if len(params) > 0 {
+			sort.Strings(params)
+			reason = fmt.Sprintf("%s, %s", reason, strings.Join(params, ", "))
+		}
+		msg = fmt.Sprintf("%s (%s)", msg, reason)
+	}
+
+	return msg
+}
+
+func (c *Endpoint) newClusterStateError(clusterId string, cluster *models.ClustersGetResponse) *ClusterStateError {
+	err := ClusterStateError{
+		ClusterId:    clusterId,
+		StateMessage: cluster.StateMessage,
+	}
+	if cluster.State != nil {
+		err.State = *cluster.State
+	}
+	if cluster.TerminationReason != nil {
+		if cluster.TerminationReason.Code != nil {
+			err.TerminationCode = *cluster.TerminationReason.Code
+		}
+		err.TerminationParameters = cluster.TerminationReason.Parameters
+	}
+
+	order := models.DESC
+	resp, eventsErr := c.Events(&models.ClustersEventsRequest{
+		ClusterId: clusterId,
+		Order:     &order,
+		Limit:     ErrorEventsLimit,
+	})
+	if eventsErr != nil {
+		log.Printf("[ERROR] Unable to fetch events of cluster %s: %v", clusterId, eventsErr)
+	} else {
+		err.Events = resp.Events
+	}
+
+	return &err
+}
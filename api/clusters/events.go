@@ -0,0 +1,185 @@
+package clusters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/tcz001/databricks-sdk-go/models"
+)
+
+// DefaultFollowInterval is the polling interval used by Follow.
+const DefaultFollowInterval = 10 * time.Second
+
+// Events returns a single page of events. The next page, if any, is described
+// by resp.NextPage; use EventIterator or AllEvents to page automatically.
+func (c *Endpoint) Events(request *models.ClustersEventsRequest) (*models.ClustersEventsResponse, error) {
+	bytes, err := c.Client.Query("POST", "clusters/events", request)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := models.ClustersEventsResponse{}
+	err = json.Unmarshal(bytes, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// AllEvents returns every event matching request across all pages.
+func (c *Endpoint) AllEvents(request *models.ClustersEventsRequest) ([]models.ClustersClusterEvent, error) {
+	var events []models.ClustersClusterEvent
+	it := c.EventIterator(request)
+	for it.Next() {
+		events = append(events, it.Event())
+	}
+
+	return events, it.Err()
+}
+
+// EventIterator iterates over cluster events, fetching pages on demand:
+//
+//	it := endpoint.EventIterator(&models.ClustersEventsRequest{ClusterId: id})
+//	for it.Next() {
+//		log.Println(it.Event().Type_)
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type EventIterator struct {
+	endpoint *Endpoint
+	request  *models.ClustersEventsRequest
+	page     []models.ClustersClusterEvent
+	current  models.ClustersClusterEvent
+	err      error
+}
+
+// EventIterator returns an iterator over the events matching request. A nil
+// request is reported by Err.
+func (c *Endpoint) EventIterator(request *models.ClustersEventsRequest) *EventIterator {
+	if request == nil {
+		return &EventIterator{endpoint: c, err: fmt.Errorf("missing events request")}
+	}
+
+	r := *request
+	return &EventIterator{endpoint: c, request: &r}
+}
+
+// Next advances to the next event. It returns false once all events were
+// read or a request failed.
+func (it *EventIterator) Next() bool {
+	for len(it.page) == 0 {
+		if it.request == nil || it.err != nil {
+			return false
+		}
+
+		resp, err := it.endpoint.Events(it.request)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.page = resp.Events
+		it.request = resp.NextPage
+		if len(resp.Events) == 0 {
+			it.request = nil
+		}
+	}
+
+	it.current = it.page[0]
+	it.page = it.page[1:]
+	return true
+}
+
+func (it *EventIterator) Event() models.ClustersClusterEvent {
+	return it.current
+}
+
+func (it *EventIterator) Err() error {
+	return it.err
+}
+
+type FollowOptions struct {
+	// StartTime only streams events newer than the given time, in epoch
+	// milliseconds. It defaults to the time Follow is called.
+	StartTime int64
+
+	EventTypes []models.ClustersEventType
+
+	// Interval is the polling interval. It defaults to DefaultFollowInterval.
+	Interval time.Duration
+}
+
+// Follow streams new events of a cluster to fn in chronological order until
+// the cluster is terminated or in error, ctx is done or fn returns an error.
+// Events emitted up to the termination are delivered before Follow returns.
+func (c *Endpoint) Follow(
+	ctx context.Context,
+	clusterId string,
+	opts FollowOptions,
+	fn func(event models.ClustersClusterEvent) error,
+) error {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultFollowInterval
+	}
+	since := opts.StartTime
+	if since == 0 {
+		since = time.Now().UnixNano() / int64(time.Millisecond)
+	}
+
+	// Events are queried from the timestamp of the last delivered event,
+	// since more events may share that millisecond. The ones already
+	// delivered are recognized by their content.
+	seen := map[string]bool{}
+
+	order := models.ASC
+	for {
+		state, err := c.getState(clusterId)
+		if err != nil {
+			return err
+		}
+
+		it := c.EventIterator(&models.ClustersEventsRequest{
+			ClusterId:  clusterId,
+			StartTime:  since,
+			Order:      &order,
+			EventTypes: opts.EventTypes,
+		})
+		for it.Next() {
+			event := it.Event()
+			key, err := json.Marshal(event)
+			if err != nil {
+				return err
+			}
+			if seen[string(key)] {
+				continue
+			}
+			if event.Timestamp > since {
+				since = event.Timestamp
+				seen = map[string]bool{}
+			}
+			seen[string(key)] = true
+
+			err = fn(event)
+			if err != nil {
+				return err
+			}
+		}
+		if it.Err() != nil {
+			return it.Err()
+		}
+
+		if state != nil && (*state == models.TERMINATED || *state == models.ERROR_) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(opts.Interval):
+		}
+	}
+}
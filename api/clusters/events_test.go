@@ -0,0 +1,143 @@
+package clusters
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/tcz001/databricks-sdk-go/client"
+	"github.com/tcz001/databricks-sdk-go/models"
+	"gopkg.in/h2non/gock.v1"
+)
+
+type EventsTestSuite struct {
+	suite.Suite
+	endpoint Endpoint
+}
+
+func (s *EventsTestSuite) SetupTest() {
+	domain := "server.com"
+	token := "token"
+	cl, err := client.NewClient(client.Options{Domain: &domain, Token: &token, RateLimitPerSecond: 100})
+	s.Require().NoError(err)
+
+	s.endpoint = Endpoint{Client: cl}
+}
+
+func (s *EventsTestSuite) TearDownTest() {
+	gock.OffAll()
+}
+
+func (s *EventsTestSuite) TestEventIteratorFollowsNextPage() {
+	gock.New("https://server.com").
+		Post("^/api/2.0/clusters/events$").
+		BodyString(`"offset":2`).
+		Reply(200).
+		JSON(map[string]interface{}{
+			"events": []map[string]interface{}{
+				{"cluster_id": "1234", "timestamp": 3, "type": "RUNNING"},
+			},
+			"total_count": 3,
+		})
+	gock.New("https://server.com").
+		Post("^/api/2.0/clusters/events$").
+		BodyString(`"limit":2`).
+		Reply(200).
+		JSON(map[string]interface{}{
+			"events": []map[string]interface{}{
+				{"cluster_id": "1234", "timestamp": 1, "type": "CREATING"},
+				{"cluster_id": "1234", "timestamp": 2, "type": "STARTING"},
+			},
+			"next_page":   map[string]interface{}{"cluster_id": "1234", "offset": 2, "limit": 2},
+			"total_count": 3,
+		})
+
+	it := s.endpoint.EventIterator(&models.ClustersEventsRequest{ClusterId: "1234", Limit: 2})
+	var timestamps []int64
+	for it.Next() {
+		timestamps = append(timestamps, it.Event().Timestamp)
+	}
+	s.Require().NoError(it.Err())
+
+	s.Assert().Equal([]int64{1, 2, 3}, timestamps)
+	s.Assert().True(gock.IsDone())
+}
+
+func (s *EventsTestSuite) TestEventIteratorRejectsNilRequest() {
+	it := s.endpoint.EventIterator(nil)
+	s.Assert().False(it.Next())
+	s.Assert().EqualError(it.Err(), "missing events request")
+
+	_, err := s.endpoint.AllEvents(nil)
+	s.Assert().Error(err)
+	s.Assert().False(gock.HasUnmatchedRequest())
+}
+
+func (s *EventsTestSuite) TestFollowStopsOnceTerminated() {
+	for _, state := range []string{"RUNNING", "TERMINATED"} {
+		gock.New("https://server.com").
+			Get("^/api/2.0/clusters/get$").
+			Reply(200).
+			JSON(map[string]interface{}{"cluster_id": "1234", "state": state})
+	}
+	gock.New("https://server.com").
+		Post("^/api/2.0/clusters/events$").
+		BodyString(`"start_time":100,`).
+		Reply(200).
+		JSON(map[string]interface{}{
+			"events": []map[string]interface{}{
+				{"cluster_id": "1234", "timestamp": 100, "type": "RUNNING"},
+				{"cluster_id": "1234", "timestamp": 110, "type": "DRIVER_NOT_RESPONDING"},
+			},
+		})
+	gock.New("https://server.com").
+		Post("^/api/2.0/clusters/events$").
+		BodyString(`"start_time":110,`).
+		Reply(200).
+		JSON(map[string]interface{}{
+			"events": []map[string]interface{}{
+				{"cluster_id": "1234", "timestamp": 110, "type": "DRIVER_NOT_RESPONDING"},
+				{"cluster_id": "1234", "timestamp": 110, "type": "DRIVER_HEALTHY"},
+				{"cluster_id": "1234", "timestamp": 120, "type": "TERMINATING"},
+			},
+		})
+
+	var types []models.ClustersEventType
+	err := s.endpoint.Follow(context.Background(), "1234", FollowOptions{StartTime: 100, Interval: time.Millisecond},
+		func(event models.ClustersClusterEvent) error {
+			types = append(types, *event.Type_)
+			return nil
+		})
+	s.Require().NoError(err)
+
+	s.Assert().Equal([]models.ClustersEventType{
+		models.RUNNING_, models.DRIVER_NOT_RESPONDING, models.DRIVER_HEALTHY, models.TERMINATING_,
+	}, types)
+	s.Assert().True(gock.IsDone())
+	s.Assert().False(gock.HasUnmatchedRequest())
+}
+
+func (s *EventsTestSuite) TestFollowStopsOnError() {
+	gock.New("https://server.com").
+		Get("^/api/2.0/clusters/get$").
+		Reply(200).
+		JSON(map[string]interface{}{"cluster_id": "1234", "state": "ERROR"})
+	gock.New("https://server.com").
+		Post("^/api/2.0/clusters/events$").
+		Reply(200).
+		JSON(map[string]interface{}{"events": []map[string]interface{}{}})
+
+	err := s.endpoint.Follow(context.Background(), "1234", FollowOptions{StartTime: 100, Interval: time.Millisecond},
+		func(event models.ClustersClusterEvent) error {
+			return nil
+		})
+	s.Require().NoError(err)
+
+	s.Assert().True(gock.IsDone())
+	s.Assert().False(gock.HasUnmatchedRequest())
+}
+
+func TestEventsTestSuite(t *testing.T) {
+	suite.Run(t, new(EventsTestSuite))
+}
@@ -0,0 +1,87 @@
+package clusters
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tcz001/databricks-sdk-go/models"
+)
+
+// DefaultProtectionTag is the custom tag marking clusters SafeDelete and
+// SafePermanentDelete refuse to remove.
+const DefaultProtectionTag = "protected"
+
+var ErrClusterProtected = errors.New("refusing to delete protected cluster")
+
+// Pin keeps the cluster configuration after termination, preventing it from
+// being removed automatically.
+func (c *Endpoint) Pin(request *models.ClustersPinRequest) error {
+	_, err := c.Client.Query("POST", "clusters/pin", request)
+	return err
+}
+
+func (c *Endpoint) Unpin(request *models.ClustersUnpinRequest) error {
+	_, err := c.Client.Query("POST", "clusters/unpin", request)
+	return err
+}
+
+// IsPinned reports whether a cluster returned by List is pinned.
+func IsPinned(cluster models.ClustersClusterInfo) bool {
+	return cluster.PinnedByUserName != ""
+}
+
+type SafeDeleteOptions struct {
+	// Force deletes the cluster even if it is pinned or protected.
+	Force bool
+
+	// ProtectionTag is the custom tag marking protected clusters. It defaults
+	// to DefaultProtectionTag. A tag with the value "false" does not protect
+	// the cluster.
+	ProtectionTag string
+}
+
+// SafeDelete terminates the cluster unless it is pinned or carries the
+// protection tag.
+func (c *Endpoint) SafeDelete(request *models.ClustersDeleteRequest, opts SafeDeleteOptions) error {
+	err := c.checkProtected(request.ClusterId, opts)
+	if err != nil {
+		return err
+	}
+
+	return c.Delete(request)
+}
+
+// SafePermanentDelete permanently deletes the cluster unless it is pinned or
+// carries the protection tag.
+func (c *Endpoint) SafePermanentDelete(request *models.ClustersPermanentDeleteRequest, opts SafeDeleteOptions) error {
+	err := c.checkProtected(request.ClusterId, opts)
+	if err != nil {
+		return err
+	}
+
+	return c.PermanentDelete(request)
+}
+
+func (c *Endpoint) checkProtected(clusterId string, opts SafeDeleteOptions) error {
+	if opts.Force {
+		return nil
+	}
+	if opts.ProtectionTag == "" {
+		opts.ProtectionTag = DefaultProtectionTag
+	}
+
+	cluster, err := c.Get(&models.ClustersGetRequest{ClusterId: clusterId})
+	if err != nil {
+		return err
+	}
+
+	if cluster.PinnedByUserName != "" {
+		return fmt.Errorf("%w %s: pinned by %s", ErrClusterProtected, clusterId, cluster.PinnedByUserName)
+	}
+	if value, ok := cluster.CustomTags[opts.ProtectionTag]; ok && !strings.EqualFold(value, "false") {
+		return fmt.Errorf("%w %s: tagged %s=%s", ErrClusterProtected, clusterId, opts.ProtectionTag, value)
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package clusters
+
+import "github.com/tcz001/databricks-sdk-go/models"
+
+// Resize changes the number of workers of a running cluster, either to a fixed
+// NumWorkers or to an Autoscale range.
+func (c *Endpoint) Resize(request *models.ClustersResizeRequest) error {
+	err := validateSize(request.NumWorkers, request.Autoscale)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Client.Query("POST", "clusters/resize", request)
+	return err
+}
+
+// ResizeSync resizes the cluster and waits until it is RUNNING with a number
+// of executors matching the request.
+func (c *Endpoint) ResizeSync(request *models.ClustersResizeRequest, opts ...WaitOptions) error {
+	err := c.Resize(request)
+	if err != nil {
+		return err
+	}
+
+	return c.wait(request.ClusterId, waitOptions(opts), models.RUNNING, func(cluster *models.ClustersGetResponse) (bool, error) {
+		if cluster.State != nil && *cluster.State == models.RESIZING {
+			return false, nil
+		}
+		if cluster.State == nil || *cluster.State != models.RUNNING {
+			return false, c.newClusterStateError(request.ClusterId, cluster)
+		}
+
+		return resized(request, int32(len(cluster.Executors))), nil
+	})
+}
+
+func resized(request *models.ClustersResizeRequest, executors int32) bool {
+	if request.Autoscale != nil {
+		return executors >= request.Autoscale.MinWorkers && executors <= request.Autoscale.MaxWorkers
+	}
+
+	return executors == request.NumWorkers
+}
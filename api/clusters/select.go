@@ -0,0 +1,135 @@
+package clusters
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tcz001/databricks-sdk-go/models"
+)
+
+// LatestLTSSparkVersion returns the key of the most recent long term support
+// runtime. The ml, gpu and photon flags select the matching runtime flavour;
+// GPU runtimes only exist for machine learning.
+func (c *Endpoint) LatestLTSSparkVersion(ml bool, gpu bool, photon bool) (string, error) {
+	resp, err := c.SparkVersions()
+	if err != nil {
+		return "", err
+	}
+
+	return LatestLTSSparkVersion(resp.Versions, ml, gpu, photon)
+}
+
+// LatestLTSSparkVersion selects the most recent long term support runtime
+// from versions, see Endpoint.LatestLTSSparkVersion.
+func LatestLTSSparkVersion(versions []models.ClustersSparkVersion, ml bool, gpu bool, photon bool) (string, error) {
+	var candidates []models.ClustersSparkVersion
+	for _, v := range versions {
+		if !strings.Contains(v.Name, "LTS") {
+			continue
+		}
+		if strings.Contains(v.Key, "-ml-") != ml ||
+			strings.Contains(v.Key, "-gpu-") != gpu ||
+			strings.Contains(v.Key, "-photon-") != photon ||
+			strings.Contains(v.Key, "-aarch64-") {
+			continue
+		}
+		if _, ok := parseRuntimeVersion(v.Key); !ok {
+			continue
+		}
+
+		candidates = append(candidates, v)
+	}
+
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("no LTS spark version found (ml: %t, gpu: %t, photon: %t)", ml, gpu, photon)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		vi, _ := parseRuntimeVersion(candidates[i].Key)
+		vj, _ := parseRuntimeVersion(candidates[j].Key)
+		if vi[0] != vj[0] {
+			return vi[0] > vj[0]
+		}
+		return vi[1] > vj[1]
+	})
+
+	return candidates[0].Key, nil
+}
+
+// parseRuntimeVersion parses the major and minor version of keys such as
+// "10.4.x-scala2.12".
+func parseRuntimeVersion(key string) ([2]int, bool) {
+	parts := strings.SplitN(key, ".", 3)
+	if len(parts) < 3 {
+		return [2]int{}, false
+	}
+
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return [2]int{}, false
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return [2]int{}, false
+	}
+
+	return [2]int{major, minor}, true
+}
+
+// SmallestNodeType returns the id of the node type with the least memory, and
+// then the fewest cores, that has at least minMemoryGB of memory and minCores
+// cores. With localDisk set only node types with local storage are
+// considered. Deprecated and hidden node types are ignored.
+func (c *Endpoint) SmallestNodeType(minMemoryGB int32, minCores int32, localDisk bool) (string, error) {
+	resp, err := c.ListNodeTypes()
+	if err != nil {
+		return "", err
+	}
+
+	return SmallestNodeType(resp.NodeTypes, minMemoryGB, minCores, localDisk)
+}
+
+// SmallestNodeType selects the smallest matching node type from nodeTypes,
+// see Endpoint.SmallestNodeType.
+func SmallestNodeType(nodeTypes []models.ClustersNodeType, minMemoryGB int32, minCores int32, localDisk bool) (string, error) {
+	var candidates []models.ClustersNodeType
+	for _, n := range nodeTypes {
+		if n.IsDeprecated || n.IsHidden {
+			continue
+		}
+		if n.MemoryMb < minMemoryGB*1024 || n.NumCores < float32(minCores) {
+			continue
+		}
+		if localDisk && !hasLocalDisk(n) {
+			continue
+		}
+
+		candidates = append(candidates, n)
+	}
+
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("no node type with at least %d GB memory and %d cores found", minMemoryGB, minCores)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].MemoryMb != candidates[j].MemoryMb {
+			return candidates[i].MemoryMb < candidates[j].MemoryMb
+		}
+		if candidates[i].NumCores != candidates[j].NumCores {
+			return candidates[i].NumCores < candidates[j].NumCores
+		}
+		return candidates[i].NodeTypeId < candidates[j].NodeTypeId
+	})
+
+	return candidates[0].NodeTypeId, nil
+}
+
+func hasLocalDisk(n models.ClustersNodeType) bool {
+	if n.NodeInstanceType == nil {
+		return false
+	}
+
+	return n.NodeInstanceType.LocalDisks > 0 || n.NodeInstanceType.LocalNvmeDisks > 0
+}
@@ -0,0 +1,96 @@
+package clusters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/tcz001/databricks-sdk-go/client"
+	"gopkg.in/h2non/gock.v1"
+)
+
+type SelectTestSuite struct {
+	suite.Suite
+	endpoint Endpoint
+}
+
+func (s *SelectTestSuite) SetupTest() {
+	domain := "server.com"
+	token := "token"
+	cl, err := client.NewClient(client.Options{Domain: &domain, Token: &token, RateLimitPerSecond: 100})
+	s.Require().NoError(err)
+
+	s.endpoint = Endpoint{Client: cl}
+}
+
+func (s *SelectTestSuite) TearDownTest() {
+	gock.Off()
+}
+
+func (s *SelectTestSuite) TestLatestLTSSparkVersion() {
+	gock.New("https://server.com").
+		Get("^/api/2.0/clusters/spark-versions$").
+		Persist().
+		Reply(200).
+		JSON(map[string]interface{}{
+			"versions": []map[string]string{
+				{"key": "9.1.x-scala2.12", "name": "9.1 LTS (includes Apache Spark 3.1.2, Scala 2.12)"},
+				{"key": "10.4.x-scala2.12", "name": "10.4 LTS (includes Apache Spark 3.2.1, Scala 2.12)"},
+				{"key": "11.0.x-scala2.12", "name": "11.0 (includes Apache Spark 3.3.0, Scala 2.12)"},
+				{"key": "10.4.x-cpu-ml-scala2.12", "name": "10.4 LTS ML (includes Apache Spark 3.2.1, Scala 2.12)"},
+				{"key": "10.4.x-gpu-ml-scala2.12", "name": "10.4 LTS ML (GPU, Scala 2.12, Spark 3.2.1)"},
+				{"key": "10.4.x-photon-scala2.12", "name": "10.4 LTS Photon (includes Apache Spark 3.2.1, Scala 2.12)"},
+				{"key": "10.4.x-aarch64-scala2.12", "name": "10.4 LTS aarch64 (includes Apache Spark 3.2.1, Scala 2.12)"},
+			},
+		})
+
+	key, err := s.endpoint.LatestLTSSparkVersion(false, false, false)
+	s.Require().NoError(err)
+	s.Assert().Equal("10.4.x-scala2.12", key)
+
+	key, err = s.endpoint.LatestLTSSparkVersion(true, true, false)
+	s.Require().NoError(err)
+	s.Assert().Equal("10.4.x-gpu-ml-scala2.12", key)
+
+	key, err = s.endpoint.LatestLTSSparkVersion(false, false, true)
+	s.Require().NoError(err)
+	s.Assert().Equal("10.4.x-photon-scala2.12", key)
+
+	_, err = s.endpoint.LatestLTSSparkVersion(false, true, false)
+	s.Assert().Error(err)
+}
+
+func (s *SelectTestSuite) TestSmallestNodeType() {
+	gock.New("https://server.com").
+		Get("^/api/2.0/clusters/list-node-types$").
+		Persist().
+		Reply(200).
+		JSON(map[string]interface{}{
+			"node_types": []map[string]interface{}{
+				{"node_type_id": "m4.large", "memory_mb": 8192, "num_cores": 2, "is_deprecated": true},
+				{"node_type_id": "m5.large", "memory_mb": 8192, "num_cores": 2},
+				{"node_type_id": "m5.xlarge", "memory_mb": 16384, "num_cores": 4},
+				{"node_type_id": "m5d.xlarge", "memory_mb": 16384, "num_cores": 4,
+					"node_instance_type": map[string]interface{}{"local_disks": 1, "local_disk_size_gb": 150}},
+				{"node_type_id": "r5.large", "memory_mb": 16384, "num_cores": 2},
+			},
+		})
+
+	id, err := s.endpoint.SmallestNodeType(4, 2, false)
+	s.Require().NoError(err)
+	s.Assert().Equal("m5.large", id)
+
+	id, err = s.endpoint.SmallestNodeType(16, 2, false)
+	s.Require().NoError(err)
+	s.Assert().Equal("r5.large", id)
+
+	id, err = s.endpoint.SmallestNodeType(8, 4, true)
+	s.Require().NoError(err)
+	s.Assert().Equal("m5d.xlarge", id)
+
+	_, err = s.endpoint.SmallestNodeType(64, 0, false)
+	s.Assert().Error(err)
+}
+
+func TestSelectSuite(t *testing.T) {
+	suite.Run(t, new(SelectTestSuite))
+}
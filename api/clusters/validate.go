@@ -0,0 +1,88 @@
+package clusters
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tcz001/databricks-sdk-go/models"
+)
+
+// validateSize rejects requests setting both a fixed size and autoscaling, and
+// autoscaling ranges with more min than max workers.
+func validateSize(numWorkers int32, autoscale *models.ClustersAutoScale) error {
+	if autoscale == nil {
+		return nil
+	}
+
+	if numWorkers > 0 {
+		return fmt.Errorf("num_workers and autoscale are mutually exclusive")
+	}
+	if autoscale.MinWorkers > autoscale.MaxWorkers {
+		return fmt.Errorf("autoscale min_workers (%d) is greater than max_workers (%d)",
+			autoscale.MinWorkers, autoscale.MaxWorkers)
+	}
+
+	return nil
+}
+
+// validateCloud rejects requests setting the attributes of more than one
+// cloud provider.
+func validateCloud(
+	aws *models.ClustersAwsAttributes,
+	azure *models.ClustersAzureAttributes,
+	gcp *models.ClustersGcpAttributes,
+) error {
+	var set []string
+	if aws != nil {
+		set = append(set, "aws_attributes")
+	}
+	if azure != nil {
+		set = append(set, "azure_attributes")
+	}
+	if gcp != nil {
+		set = append(set, "gcp_attributes")
+	}
+
+	if len(set) > 1 {
+		return fmt.Errorf("only one of aws_attributes, azure_attributes and gcp_attributes can be set, got %s",
+			strings.Join(set, " and "))
+	}
+
+	return nil
+}
+
+// validateAws checks EBS volume and spot settings.
+func validateAws(attributes *models.ClustersAwsAttributes) []error {
+	var errs []error
+
+	if (attributes.EbsVolumeCount > 0) != (attributes.EbsVolumeSize > 0) {
+		errs = append(errs, fmt.Errorf("ebs_volume_count and ebs_volume_size must be set together"))
+	}
+	if attributes.EbsVolumeCount > 10 {
+		errs = append(errs, fmt.Errorf("ebs_volume_count (%d) cannot exceed 10", attributes.EbsVolumeCount))
+	}
+	if attributes.EbsVolumeSize > 0 && attributes.EbsVolumeType != nil {
+		minSize := int32(100)
+		if *attributes.EbsVolumeType == models.THROUGHPUT_OPTIMIZED_HDD {
+			minSize = 500
+		}
+		if attributes.EbsVolumeSize < minSize || attributes.EbsVolumeSize > 4096 {
+			errs = append(errs, fmt.Errorf("ebs_volume_size (%d) must be between %d and 4096 for %s",
+				attributes.EbsVolumeSize, minSize, *attributes.EbsVolumeType))
+		}
+	}
+	minBid := int32(0)
+	if attributes.Availability != nil &&
+		(*attributes.Availability == models.SPOT || *attributes.Availability == models.SPOT_WITH_FALLBACK) {
+		minBid = 1
+	}
+	if attributes.SpotBidPricePercent < minBid || attributes.SpotBidPricePercent > 10000 {
+		errs = append(errs, fmt.Errorf("spot_bid_price_percent (%d) must be between 1 and 10000",
+			attributes.SpotBidPricePercent))
+	}
+	if attributes.FirstOnDemand < 0 {
+		errs = append(errs, fmt.Errorf("first_on_demand (%d) cannot be negative", attributes.FirstOnDemand))
+	}
+
+	return errs
+}
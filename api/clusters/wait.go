@@ -0,0 +1,131 @@
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tcz001/databricks-sdk-go/models"
+)
+
+const (
+	DefaultWaitTimeout         = 30 * time.Minute
+	DefaultInitialPollInterval = 10 * time.Second
+	DefaultMaxPollInterval     = 30 * time.Second
+)
+
+// WaitOptions configures how the Sync methods and WaitForState poll the
+// cluster state. Zero values fall back to the defaults above.
+type WaitOptions struct {
+	// Context cancels the wait. It defaults to context.Background().
+	Context context.Context
+
+	Timeout time.Duration
+
+	// InitialPollInterval is doubled after every poll until it reaches
+	// MaxPollInterval.
+	InitialPollInterval time.Duration
+	MaxPollInterval     time.Duration
+
+	// OnStateChange is called whenever a new state is observed, including
+	// the first one, for which from is empty.
+	OnStateChange func(clusterId string, from models.ClustersClusterState, to models.ClustersClusterState)
+}
+
+func waitOptions(opts []WaitOptions) WaitOptions {
+	o := WaitOptions{}
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+
+	if o.Context == nil {
+		o.Context = context.Background()
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = DefaultWaitTimeout
+	}
+	if o.InitialPollInterval <= 0 {
+		o.InitialPollInterval = DefaultInitialPollInterval
+	}
+	if o.MaxPollInterval < o.InitialPollInterval {
+		o.MaxPollInterval = DefaultMaxPollInterval
+		if o.MaxPollInterval < o.InitialPollInterval {
+			o.MaxPollInterval = o.InitialPollInterval
+		}
+	}
+
+	return o
+}
+
+// WaitForState polls the cluster until it reaches target. Any state other
+// than target and allowedIntermediate fails with a *ClusterStateError.
+func (c *Endpoint) WaitForState(
+	clusterId string,
+	target models.ClustersClusterState,
+	allowedIntermediate []models.ClustersClusterState,
+	opts ...WaitOptions,
+) error {
+	allowed := make(map[models.ClustersClusterState]bool, len(allowedIntermediate))
+	for _, v := range allowedIntermediate {
+		allowed[v] = true
+	}
+
+	return c.wait(clusterId, waitOptions(opts), target, func(cluster *models.ClustersGetResponse) (bool, error) {
+		if cluster.State != nil && *cluster.State == target {
+			return true, nil
+		}
+
+		if cluster.State == nil || !allowed[*cluster.State] {
+			return false, c.newClusterStateError(clusterId, cluster)
+		}
+
+		return false, nil
+	})
+}
+
+// wait polls the cluster until check reports it is done or fails. target is
+// only used to describe timeouts.
+func (c *Endpoint) wait(
+	clusterId string,
+	o WaitOptions,
+	target models.ClustersClusterState,
+	check func(cluster *models.ClustersGetResponse) (bool, error),
+) error {
+	ctx, cancel := context.WithTimeout(o.Context, o.Timeout)
+	defer cancel()
+
+	var last models.ClustersClusterState
+	interval := o.InitialPollInterval
+	for {
+		cluster, err := c.Get(&models.ClustersGetRequest{ClusterId: clusterId})
+		if err != nil {
+			return err
+		}
+
+		if cluster.State != nil && *cluster.State != last {
+			if o.OnStateChange != nil {
+				o.OnStateChange(clusterId, last, *cluster.State)
+			}
+			last = *cluster.State
+		}
+
+		done, err := check(cluster)
+		if err != nil || done {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			if o.Context.Err() != nil {
+				return o.Context.Err()
+			}
+			return fmt.Errorf("timeout when waiting for cluster %s to have state %s", clusterId, target)
+		case <-time.After(interval):
+		}
+
+		interval *= 2
+		if interval > o.MaxPollInterval {
+			interval = o.MaxPollInterval
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package repos
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/tcz001/databricks-sdk-go/client"
+	"github.com/tcz001/databricks-sdk-go/models"
+)
+
+type Endpoint struct {
+	Client *client.Client
+}
+
+func (c *Endpoint) Create(request *models.ReposCreateRequest) (*models.ReposRepoInfo, error) {
+	bytes, err := c.Client.Query("POST", "repos", request)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := models.ReposRepoInfo{}
+	err = json.Unmarshal(bytes, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *Endpoint) Get(repoId int64) (*models.ReposRepoInfo, error) {
+	bytes, err := c.Client.Query("GET", fmt.Sprintf("repos/%d", repoId), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := models.ReposRepoInfo{}
+	err = json.Unmarshal(bytes, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// List returns a single page of repos. Use ListAll to follow next_page_token.
+func (c *Endpoint) List(request *models.ReposListRequest) (*models.ReposListResponse, error) {
+	query := url.Values{}
+	if request != nil {
+		if request.PathPrefix != "" {
+			query.Set("path_prefix", request.PathPrefix)
+		}
+		if request.NextPageToken != "" {
+			query.Set("next_page_token", request.NextPageToken)
+		}
+	}
+
+	listUrl := "repos"
+	if len(query) > 0 {
+		listUrl = fmt.Sprintf("%s?%s", listUrl, query.Encode())
+	}
+
+	bytes, err := c.Client.Query("GET", listUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := models.ReposListResponse{}
+	err = json.Unmarshal(bytes, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// ListAll returns every repo below pathPrefix.
+func (c *Endpoint) ListAll(pathPrefix string) ([]models.ReposRepoInfo, error) {
+	request := models.ReposListRequest{PathPrefix: pathPrefix}
+	var repos []models.ReposRepoInfo
+	for {
+		resp, err := c.List(&request)
+		if err != nil {
+			return nil, err
+		}
+		repos = append(repos, resp.Repos...)
+
+		if resp.NextPageToken == "" {
+			return repos, nil
+		}
+		request.NextPageToken = resp.NextPageToken
+	}
+}
+
+// Update checks out a branch or tag, or changes the sparse checkout patterns
+// of a repo. Branch and Tag are mutually exclusive.
+func (c *Endpoint) Update(repoId int64, request *models.ReposUpdateRequest) error {
+	if request.Branch != "" && request.Tag != "" {
+		return fmt.Errorf("branch and tag are mutually exclusive")
+	}
+
+	_, err := c.Client.Query("PATCH", fmt.Sprintf("repos/%d", repoId), request)
+	return err
+}
+
+// CheckoutBranch switches the repo to the head of branch.
+func (c *Endpoint) CheckoutBranch(repoId int64, branch string) error {
+	return c.Update(repoId, &models.ReposUpdateRequest{Branch: branch})
+}
+
+// CheckoutTag switches the repo to tag, leaving it in a detached HEAD state.
+func (c *Endpoint) CheckoutTag(repoId int64, tag string) error {
+	return c.Update(repoId, &models.ReposUpdateRequest{Tag: tag})
+}
+
+// SetSparseCheckout replaces the cone patterns of a repo created with sparse
+// checkout enabled.
+func (c *Endpoint) SetSparseCheckout(repoId int64, patterns []string) error {
+	return c.Update(repoId, &models.ReposUpdateRequest{
+		SparseCheckout: &models.ReposSparseCheckout{Patterns: patterns},
+	})
+}
+
+func (c *Endpoint) Delete(repoId int64) error {
+	_, err := c.Client.Query("DELETE", fmt.Sprintf("repos/%d", repoId), nil)
+	return err
+}
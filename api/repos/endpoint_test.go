@@ -0,0 +1,101 @@
+package repos
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/tcz001/databricks-sdk-go/client"
+	"github.com/tcz001/databricks-sdk-go/models"
+	"gopkg.in/h2non/gock.v1"
+)
+
+type EndpointTestSuite struct {
+	suite.Suite
+	endpoint Endpoint
+}
+
+func (s *EndpointTestSuite) SetupTest() {
+	domain := "server.com"
+	token := "a_token"
+	cl, err := client.NewClient(client.Options{Domain: &domain, Token: &token, RateLimitPerSecond: 100})
+	s.Require().NoError(err)
+
+	s.endpoint = Endpoint{Client: cl}
+}
+
+func (s *EndpointTestSuite) TearDownTest() {
+	gock.OffAll()
+}
+
+func (s *EndpointTestSuite) TestListAllFollowsNextPageToken() {
+	gock.New("https://server.com").
+		Get("^/api/2.0/repos$").
+		AddMatcher(func(req *http.Request, _ *gock.Request) (bool, error) {
+			return req.URL.RawQuery == "path_prefix=%2FRepos%2Fme", nil
+		}).
+		Reply(200).
+		JSON(map[string]interface{}{
+			"repos":           []map[string]interface{}{{"id": 1, "path": "/Repos/me/a"}},
+			"next_page_token": "page2",
+		})
+	gock.New("https://server.com").
+		Get("^/api/2.0/repos$").
+		MatchParam("path_prefix", "^/Repos/me$").
+		MatchParam("next_page_token", "^page2$").
+		Reply(200).
+		JSON(map[string]interface{}{
+			"repos": []map[string]interface{}{{"id": 2, "path": "/Repos/me/b"}},
+		})
+
+	repos, err := s.endpoint.ListAll("/Repos/me")
+	s.Require().NoError(err)
+
+	s.Require().Len(repos, 2)
+	s.Assert().Equal(int64(1), repos[0].Id)
+	s.Assert().Equal("/Repos/me/b", repos[1].Path)
+	s.Assert().True(gock.IsDone())
+	s.Assert().False(gock.HasUnmatchedRequest())
+}
+
+func (s *EndpointTestSuite) TestUpdateRejectsBranchAndTag() {
+	err := s.endpoint.Update(1, &models.ReposUpdateRequest{Branch: "main", Tag: "v1"})
+	s.Assert().EqualError(err, "branch and tag are mutually exclusive")
+	s.Assert().False(gock.HasUnmatchedRequest())
+}
+
+func (s *EndpointTestSuite) TestCheckoutBranchAndTag() {
+	gock.New("https://server.com").
+		Patch("^/api/2.0/repos/1$").
+		BodyString(`^{"branch":"main"}$`).
+		Reply(200).
+		JSON(map[string]interface{}{})
+	gock.New("https://server.com").
+		Patch("^/api/2.0/repos/1$").
+		BodyString(`^{"tag":"v1"}$`).
+		Reply(200).
+		JSON(map[string]interface{}{})
+
+	s.Require().NoError(s.endpoint.CheckoutBranch(1, "main"))
+	s.Require().NoError(s.endpoint.CheckoutTag(1, "v1"))
+
+	s.Assert().True(gock.IsDone())
+	s.Assert().False(gock.HasUnmatchedRequest())
+}
+
+func (s *EndpointTestSuite) TestSetSparseCheckout() {
+	gock.New("https://server.com").
+		Patch("^/api/2.0/repos/1$").
+		BodyString(`^{"sparse_checkout":{"patterns":\["etl","jobs/daily"\]}}$`).
+		Reply(200).
+		JSON(map[string]interface{}{})
+
+	s.Require().NoError(s.endpoint.SetSparseCheckout(1, []string{"etl", "jobs/daily"}))
+
+	s.Assert().True(gock.IsDone())
+	s.Assert().False(gock.HasUnmatchedRequest())
+}
+
+func TestEndpointTestSuite(t *testing.T) {
+	suite.Run(t, new(EndpointTestSuite))
+}
@@ -8,6 +8,12 @@ import (
 	"github.com/tcz001/databricks-sdk-go/models"
 )
 
+const (
+	UserSchema             = "urn:ietf:params:scim:schemas:core:2.0:User"
+	GroupSchema            = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	ServicePrincipalSchema = "urn:ietf:params:scim:schemas:core:2.0:ServicePrincipal"
+)
+
 type Endpoint struct {
 	Client *client.Client
 }
@@ -45,7 +51,12 @@ func (c *Endpoint) GetServicePrincipal(id string) (*models.ServicePrincipal, err
 }
 
 func (c *Endpoint) CreateServicePrincipal(request *models.ServicePrincipalCreateRequest) (*models.ServicePrincipal, error) {
-	bytes, err := c.Client.Query("POST", "preview/scim/v2/ServicePrincipals", request)
+	withSchemas := *request
+	if len(withSchemas.Schemas) == 0 {
+		withSchemas.Schemas = []string{ServicePrincipalSchema}
+	}
+
+	bytes, err := c.Client.Query("POST", "preview/scim/v2/ServicePrincipals", &withSchemas)
 	if err != nil {
 		return nil, err
 	}
@@ -105,7 +116,12 @@ func (c *Endpoint) ListGroups() (*models.ListGroupRequestScim, error) {
 }
 
 func (c *Endpoint) CreateGroup(request *models.ScimGroup) (*models.ScimGroup, error) {
-	bytes, err := c.Client.Query("POST", "preview/scim/v2/Groups", request)
+	withSchemas := *request
+	if len(withSchemas.Schemas) == 0 {
+		withSchemas.Schemas = []string{GroupSchema}
+	}
+
+	bytes, err := c.Client.Query("POST", "preview/scim/v2/Groups", &withSchemas)
 	if err != nil {
 		return nil, err
 	}
@@ -182,6 +198,10 @@ func (c *Endpoint) ListUsers() (*models.ListUserRequestScim, error) {
 }
 
 func (c *Endpoint) CreateUser(request models.ScimUser) (*models.ScimUser, error) {
+	if len(request.Schemas) == 0 {
+		request.Schemas = []string{UserSchema}
+	}
+
 	bytes, err := c.Client.Query("POST", "preview/scim/v2/Users", request)
 	if err != nil {
 		return nil, err
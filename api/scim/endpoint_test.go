@@ -0,0 +1,83 @@
+package scim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/tcz001/databricks-sdk-go/client"
+	"github.com/tcz001/databricks-sdk-go/models"
+	"gopkg.in/h2non/gock.v1"
+)
+
+type EndpointTestSuite struct {
+	suite.Suite
+	endpoint Endpoint
+}
+
+func (s *EndpointTestSuite) SetupTest() {
+	domain := "server.com"
+	token := "a_token"
+	cl, err := client.NewClient(client.Options{Domain: &domain, Token: &token, RateLimitPerSecond: 100})
+	s.Require().NoError(err)
+
+	s.endpoint = Endpoint{Client: cl}
+}
+
+func (s *EndpointTestSuite) TearDownTest() {
+	gock.OffAll()
+}
+
+func (s *EndpointTestSuite) TestCreateFillsInSchemas() {
+	for _, m := range []struct {
+		path   string
+		schema string
+	}{
+		{"ServicePrincipals", ServicePrincipalSchema},
+		{"Groups", GroupSchema},
+		{"Users", UserSchema},
+	} {
+		gock.New("https://server.com").
+			Post("^/api/2.0/preview/scim/v2/" + m.path + "$").
+			BodyString(`"schemas":\["` + m.schema + `"\]`).
+			Reply(200).
+			JSON(map[string]interface{}{"id": "1"})
+	}
+
+	sp := &models.ServicePrincipalCreateRequest{DisplayName: "ci"}
+	_, err := s.endpoint.CreateServicePrincipal(sp)
+	s.Require().NoError(err)
+
+	entitlement := "workspace-access"
+	group := &models.ScimGroup{
+		DisplayName:  "data",
+		Entitlements: []models.Entitlements{{Value: models.ALLOW_CLUSTER_CREATE}, {Value: entitlement}},
+	}
+	_, err = s.endpoint.CreateGroup(group)
+	s.Require().NoError(err)
+
+	_, err = s.endpoint.CreateUser(models.ScimUser{UserName: "me@example.com"})
+	s.Require().NoError(err)
+
+	s.Assert().Nil(sp.Schemas)
+	s.Assert().Nil(group.Schemas)
+	s.Assert().True(gock.IsDone())
+	s.Assert().False(gock.HasUnmatchedRequest())
+}
+
+func (s *EndpointTestSuite) TestCreateKeepsGivenSchemas() {
+	gock.New("https://server.com").
+		Post("^/api/2.0/preview/scim/v2/Groups$").
+		BodyString(`"schemas":\["custom"\]`).
+		Reply(200).
+		JSON(map[string]interface{}{"id": "1"})
+
+	_, err := s.endpoint.CreateGroup(&models.ScimGroup{Schemas: []string{"custom"}, DisplayName: "data"})
+	s.Require().NoError(err)
+
+	s.Assert().True(gock.IsDone())
+	s.Assert().False(gock.HasUnmatchedRequest())
+}
+
+func TestEndpointTestSuite(t *testing.T) {
+	suite.Run(t, new(EndpointTestSuite))
+}
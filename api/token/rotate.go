@@ -0,0 +1,117 @@
+package token
+
+import (
+	"fmt"
+
+	secret "github.com/tcz001/databricks-sdk-go/api/secrets"
+	"github.com/tcz001/databricks-sdk-go/client"
+	"github.com/tcz001/databricks-sdk-go/models"
+)
+
+// Sink stores a freshly created token so that its consumers can pick it up.
+type Sink interface {
+	Store(tokenValue string, info *models.TokenInfo) error
+}
+
+// SinkFunc adapts a plain function to the Sink interface.
+type SinkFunc func(tokenValue string, info *models.TokenInfo) error
+
+func (f SinkFunc) Store(tokenValue string, info *models.TokenInfo) error {
+	return f(tokenValue, info)
+}
+
+// SecretSink writes the token value into a key of a secret scope.
+type SecretSink struct {
+	Endpoint *secret.Endpoint
+	Scope    string
+	Key      string
+}
+
+func (s *SecretSink) Store(tokenValue string, info *models.TokenInfo) error {
+	return s.Endpoint.Put(&models.SecretsPutRequest{
+		Scope:       s.Scope,
+		Key:         s.Key,
+		StringValue: tokenValue,
+	})
+}
+
+type RotateOptions struct {
+	LifetimeSeconds int32
+	Comment         string
+
+	// PreviousTokenId is revoked once the new token is stored. Nothing is
+	// revoked when it is empty.
+	PreviousTokenId string
+
+	Sink Sink
+
+	// Verify is called with the new token value before it is stored. It is
+	// required, see ClientVerifier.
+	Verify func(tokenValue string) error
+}
+
+// Rotate creates a new token, verifies that it can be used, hands it to the
+// sink and finally revokes the previous token. The new token is revoked again
+// if verification or storing fails, leaving the previous token untouched.
+func (c *Endpoint) Rotate(opts RotateOptions) (*models.TokenCreateReponse, error) {
+	if opts.Sink == nil {
+		return nil, fmt.Errorf("missing token sink")
+	}
+	if opts.Verify == nil {
+		return nil, fmt.Errorf("missing token verifier")
+	}
+
+	resp, err := c.Create(&models.TokenCreateRequest{
+		LifetimeSeconds: opts.LifetimeSeconds,
+		Comment:         opts.Comment,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.TokenInfo == nil {
+		return nil, fmt.Errorf("missing token info in create response")
+	}
+
+	if err := opts.Verify(resp.TokenValue); err != nil {
+		return nil, c.rollback(resp.TokenInfo.TokenId, fmt.Errorf("verifying new token: %v", err))
+	}
+
+	if err := opts.Sink.Store(resp.TokenValue, resp.TokenInfo); err != nil {
+		return nil, c.rollback(resp.TokenInfo.TokenId, fmt.Errorf("storing new token: %v", err))
+	}
+
+	if opts.PreviousTokenId != "" {
+		err = c.Revoke(&models.TokenRevokeRequest{TokenId: opts.PreviousTokenId})
+		if err != nil {
+			return resp, fmt.Errorf("revoking previous token %s: %v", opts.PreviousTokenId, err)
+		}
+	}
+
+	return resp, nil
+}
+
+func (c *Endpoint) rollback(tokenId string, cause error) error {
+	err := c.Revoke(&models.TokenRevokeRequest{TokenId: tokenId})
+	if err != nil {
+		return fmt.Errorf("%v (revoking new token %s also failed: %v)", cause, tokenId, err)
+	}
+
+	return cause
+}
+
+// ClientVerifier returns a Verify function that builds a client from opts
+// using the new token and lists its tokens, which is a cheap authenticated
+// call.
+func ClientVerifier(opts client.Options) func(tokenValue string) error {
+	return func(tokenValue string) error {
+		opts.Token = &tokenValue
+		cl, err := client.NewClient(opts)
+		if err != nil {
+			return err
+		}
+
+		endpoint := Endpoint{Client: cl}
+		_, err = endpoint.List()
+		return err
+	}
+}
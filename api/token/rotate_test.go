@@ -0,0 +1,120 @@
+package token
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/tcz001/databricks-sdk-go/client"
+	"github.com/tcz001/databricks-sdk-go/models"
+	"gopkg.in/h2non/gock.v1"
+)
+
+type RotateTestSuite struct {
+	suite.Suite
+	endpoint Endpoint
+}
+
+func (s *RotateTestSuite) SetupTest() {
+	domain := "server.com"
+	token := "old_token"
+	cl, err := client.NewClient(client.Options{Domain: &domain, Token: &token, RateLimitPerSecond: 100})
+	s.Require().NoError(err)
+
+	s.endpoint = Endpoint{Client: cl}
+
+	gock.New("https://server.com").
+		Post("^/api/2.0/token/create$").
+		Reply(200).
+		JSON(map[string]interface{}{
+			"token_value": "new_token",
+			"token_info":  map[string]interface{}{"token_id": "new_id"},
+		})
+}
+
+func (s *RotateTestSuite) TearDownTest() {
+	gock.Off()
+}
+
+func (s *RotateTestSuite) TestRotateStoresNewTokenAndRevokesPrevious() {
+	gock.New("https://server.com").
+		Post("^/api/2.0/token/delete$").
+		BodyString(`"token_id":"old_id"`).
+		Reply(200)
+
+	var stored string
+	resp, err := s.endpoint.Rotate(RotateOptions{
+		LifetimeSeconds: 3600,
+		Comment:         "rotated",
+		PreviousTokenId: "old_id",
+		Sink: SinkFunc(func(tokenValue string, info *models.TokenInfo) error {
+			stored = tokenValue
+			return nil
+		}),
+		Verify: func(tokenValue string) error { return nil },
+	})
+	s.Require().NoError(err)
+
+	s.Assert().Equal("new_id", resp.TokenInfo.TokenId)
+	s.Assert().Equal("new_token", stored)
+	s.Assert().True(gock.IsDone())
+}
+
+func (s *RotateTestSuite) TestRotateRevokesNewTokenWhenVerificationFails() {
+	gock.New("https://server.com").
+		Post("^/api/2.0/token/delete$").
+		BodyString(`"token_id":"new_id"`).
+		Reply(200)
+
+	stored := false
+	_, err := s.endpoint.Rotate(RotateOptions{
+		PreviousTokenId: "old_id",
+		Sink: SinkFunc(func(tokenValue string, info *models.TokenInfo) error {
+			stored = true
+			return nil
+		}),
+		Verify: func(tokenValue string) error { return fmt.Errorf("unauthorized") },
+	})
+	s.Require().Error(err)
+
+	s.Assert().Equal("verifying new token: unauthorized", err.Error())
+	s.Assert().False(stored)
+	s.Assert().True(gock.IsDone())
+}
+
+func (s *RotateTestSuite) TestRotateRevokesNewTokenWhenStoringFails() {
+	gock.New("https://server.com").
+		Post("^/api/2.0/token/delete$").
+		BodyString(`"token_id":"new_id"`).
+		Reply(200)
+
+	_, err := s.endpoint.Rotate(RotateOptions{
+		PreviousTokenId: "old_id",
+		Sink: SinkFunc(func(tokenValue string, info *models.TokenInfo) error {
+			return fmt.Errorf("scope not found")
+		}),
+		Verify: func(tokenValue string) error { return nil },
+	})
+	s.Require().Error(err)
+
+	s.Assert().Equal("storing new token: scope not found", err.Error())
+	s.Assert().True(gock.IsDone())
+}
+
+func (s *RotateTestSuite) TestRotateRequiresVerifier() {
+	gock.Off()
+
+	_, err := s.endpoint.Rotate(RotateOptions{
+		PreviousTokenId: "old_id",
+		Sink: SinkFunc(func(tokenValue string, info *models.TokenInfo) error {
+			return nil
+		}),
+	})
+	s.Require().Error(err)
+
+	s.Assert().Equal("missing token verifier", err.Error())
+}
+
+func TestRotateSuite(t *testing.T) {
+	suite.Run(t, new(RotateTestSuite))
+}
@@ -0,0 +1,129 @@
+package tokenmanagement
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/tcz001/databricks-sdk-go/client"
+	"github.com/tcz001/databricks-sdk-go/models"
+)
+
+type Endpoint struct {
+	Client *client.Client
+}
+
+func (c *Endpoint) List(request *models.TokenManagementListRequest) (*models.TokenListResponse, error) {
+	query := url.Values{}
+	if request != nil {
+		if request.CreatedById != 0 {
+			query.Set("created_by_id", strconv.FormatInt(request.CreatedById, 10))
+		}
+		if request.CreatedByUsername != "" {
+			query.Set("created_by_username", request.CreatedByUsername)
+		}
+	}
+
+	listUrl := "token-management/tokens"
+	if len(query) > 0 {
+		listUrl = fmt.Sprintf("%s?%s", listUrl, query.Encode())
+	}
+
+	bytes, err := c.Client.Query("GET", listUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := models.TokenListResponse{}
+	err = json.Unmarshal(bytes, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *Endpoint) Get(tokenId string) (*models.TokenManagementGetResponse, error) {
+	if tokenId == "" {
+		return nil, fmt.Errorf("missing token id")
+	}
+	getUrl := fmt.Sprintf("token-management/tokens/%s", url.PathEscape(tokenId))
+	bytes, err := c.Client.Query("GET", getUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := models.TokenManagementGetResponse{}
+	err = json.Unmarshal(bytes, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *Endpoint) Delete(tokenId string) error {
+	if tokenId == "" {
+		return fmt.Errorf("missing token id")
+	}
+	deleteUrl := fmt.Sprintf("token-management/tokens/%s", url.PathEscape(tokenId))
+	_, err := c.Client.Query("DELETE", deleteUrl, nil)
+	return err
+}
+
+// CreateOnBehalfOf creates a token for the service principal identified by
+// request.ApplicationId. The caller needs to be a workspace admin.
+func (c *Endpoint) CreateOnBehalfOf(request *models.TokenManagementCreateOboRequest) (*models.TokenCreateReponse, error) {
+	if request.ApplicationId == "" {
+		return nil, fmt.Errorf("missing application id")
+	}
+	bytes, err := c.Client.Query("POST", "token-management/on-behalf-of/tokens", request)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := models.TokenCreateReponse{}
+	err = json.Unmarshal(bytes, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *Endpoint) GetPermissions() (*models.TokenPermissions, error) {
+	return c.queryPermissions("GET", nil)
+}
+
+// UpdatePermissions adds the given access control entries to the existing
+// token permissions.
+func (c *Endpoint) UpdatePermissions(request *models.TokenPermissionsRequest) (*models.TokenPermissions, error) {
+	return c.queryPermissions("PATCH", request)
+}
+
+// SetPermissions replaces all existing token permissions with the given
+// access control entries.
+func (c *Endpoint) SetPermissions(request *models.TokenPermissionsRequest) (*models.TokenPermissions, error) {
+	return c.queryPermissions("PUT", request)
+}
+
+func (c *Endpoint) queryPermissions(method string, request *models.TokenPermissionsRequest) (*models.TokenPermissions, error) {
+	var data interface{}
+	if request != nil {
+		data = request
+	}
+
+	bytes, err := c.Client.Query(method, "permissions/authorization/tokens", data)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := models.TokenPermissions{}
+	err = json.Unmarshal(bytes, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,163 @@
+package tokenmanagement
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/tcz001/databricks-sdk-go/client"
+	"github.com/tcz001/databricks-sdk-go/models"
+	"gopkg.in/h2non/gock.v1"
+)
+
+type EndpointTestSuite struct {
+	suite.Suite
+	endpoint Endpoint
+}
+
+func (s *EndpointTestSuite) SetupTest() {
+	domain := "server.com"
+	token := "a_token"
+	cl, err := client.NewClient(client.Options{Domain: &domain, Token: &token, RateLimitPerSecond: 100})
+	s.Require().NoError(err)
+
+	s.endpoint = Endpoint{Client: cl}
+}
+
+func (s *EndpointTestSuite) TearDownTest() {
+	gock.OffAll()
+}
+
+func (s *EndpointTestSuite) TestListFiltersByCreator() {
+	gock.New("https://server.com").
+		Get("^/api/2.0/token-management/tokens$").
+		MatchParam("created_by_id", "^42$").
+		MatchParam("created_by_username", "^me@example.com$").
+		Reply(200).
+		JSON(map[string]interface{}{
+			"token_infos": []map[string]interface{}{{"token_id": "t1", "created_by_id": 42}},
+		})
+
+	resp, err := s.endpoint.List(&models.TokenManagementListRequest{CreatedById: 42, CreatedByUsername: "me@example.com"})
+	s.Require().NoError(err)
+
+	s.Require().Len(resp.TokenInfos, 1)
+	s.Assert().Equal("t1", resp.TokenInfos[0].TokenId)
+	s.Assert().True(gock.IsDone())
+	s.Assert().False(gock.HasUnmatchedRequest())
+}
+
+func (s *EndpointTestSuite) TestListWithoutFilters() {
+	gock.New("https://server.com").
+		Get("^/api/2.0/token-management/tokens$").
+		AddMatcher(func(req *http.Request, _ *gock.Request) (bool, error) {
+			return req.URL.RawQuery == "", nil
+		}).
+		Reply(200).
+		JSON(map[string]interface{}{"token_infos": []map[string]interface{}{}})
+
+	_, err := s.endpoint.List(nil)
+	s.Require().NoError(err)
+
+	s.Assert().True(gock.IsDone())
+	s.Assert().False(gock.HasUnmatchedRequest())
+}
+
+func (s *EndpointTestSuite) TestGetAndDelete() {
+	gock.New("https://server.com").
+		Get("^/api/2.0/token-management/tokens/t1$").
+		Reply(200).
+		JSON(map[string]interface{}{"token_info": map[string]interface{}{"token_id": "t1"}})
+	gock.New("https://server.com").
+		Delete("^/api/2.0/token-management/tokens/t1$").
+		Reply(200).
+		JSON(map[string]interface{}{})
+
+	resp, err := s.endpoint.Get("t1")
+	s.Require().NoError(err)
+	s.Assert().Equal("t1", resp.TokenInfo.TokenId)
+
+	s.Require().NoError(s.endpoint.Delete("t1"))
+
+	_, err = s.endpoint.Get("")
+	s.Assert().EqualError(err, "missing token id")
+	s.Assert().EqualError(s.endpoint.Delete(""), "missing token id")
+	s.Assert().True(gock.IsDone())
+	s.Assert().False(gock.HasUnmatchedRequest())
+}
+
+func (s *EndpointTestSuite) TestCreateOnBehalfOf() {
+	gock.New("https://server.com").
+		Post("^/api/2.0/token-management/on-behalf-of/tokens$").
+		BodyString(`{"application_id":"app","lifetime_seconds":3600,"comment":"ci"}`).
+		Reply(200).
+		JSON(map[string]interface{}{
+			"token_value": "dapi123",
+			"token_info":  map[string]interface{}{"token_id": "t2"},
+		})
+
+	resp, err := s.endpoint.CreateOnBehalfOf(&models.TokenManagementCreateOboRequest{
+		ApplicationId:   "app",
+		LifetimeSeconds: 3600,
+		Comment:         "ci",
+	})
+	s.Require().NoError(err)
+
+	s.Assert().Equal("dapi123", resp.TokenValue)
+	s.Assert().Equal("t2", resp.TokenInfo.TokenId)
+
+	_, err = s.endpoint.CreateOnBehalfOf(&models.TokenManagementCreateOboRequest{})
+	s.Assert().EqualError(err, "missing application id")
+	s.Assert().True(gock.IsDone())
+	s.Assert().False(gock.HasUnmatchedRequest())
+}
+
+func (s *EndpointTestSuite) TestPermissions() {
+	level := models.CAN_USE
+	request := &models.TokenPermissionsRequest{
+		AccessControlList: []models.TokenAccessControlRequest{{GroupName: "users", PermissionLevel: &level}},
+	}
+	requestBody := `{"access_control_list":[{"group_name":"users","permission_level":"CAN_USE"}]}`
+	permissions := map[string]interface{}{
+		"object_id":   "authorization/tokens",
+		"object_type": "tokens",
+		"access_control_list": []map[string]interface{}{{
+			"group_name":      "users",
+			"all_permissions": []map[string]interface{}{{"permission_level": "CAN_USE"}},
+		}},
+	}
+
+	gock.New("https://server.com").
+		Get("^/api/2.0/permissions/authorization/tokens$").
+		Reply(200).
+		JSON(permissions)
+	gock.New("https://server.com").
+		Patch("^/api/2.0/permissions/authorization/tokens$").
+		BodyString(requestBody).
+		Reply(200).
+		JSON(permissions)
+	gock.New("https://server.com").
+		Put("^/api/2.0/permissions/authorization/tokens$").
+		BodyString(requestBody).
+		Reply(200).
+		JSON(permissions)
+
+	resp, err := s.endpoint.GetPermissions()
+	s.Require().NoError(err)
+	s.Require().Len(resp.AccessControlList, 1)
+	s.Assert().Equal("users", resp.AccessControlList[0].GroupName)
+	s.Assert().Equal(models.CAN_USE, *resp.AccessControlList[0].AllPermissions[0].PermissionLevel)
+
+	_, err = s.endpoint.UpdatePermissions(request)
+	s.Require().NoError(err)
+
+	_, err = s.endpoint.SetPermissions(request)
+	s.Require().NoError(err)
+
+	s.Assert().True(gock.IsDone())
+	s.Assert().False(gock.HasUnmatchedRequest())
+}
+
+func TestEndpointTestSuite(t *testing.T) {
+	suite.Run(t, new(EndpointTestSuite))
+}
@@ -0,0 +1,205 @@
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/tcz001/databricks-sdk-go/client"
+	"github.com/tcz001/databricks-sdk-go/models"
+)
+
+// ProtectedRoots lists the workspace roots DeleteRecursive and Move refuse to
+// remove unless forced.
+var ProtectedRoots = []string{"/", "/Users", "/Shared", "/Repos"}
+
+var ErrProtectedPath = errors.New("refusing to delete protected workspace path")
+
+// IsProtected reports whether p is one of ProtectedRoots.
+func IsProtected(p string) bool {
+	p = path.Clean("/" + p)
+	for _, root := range ProtectedRoots {
+		if p == root {
+			return true
+		}
+	}
+
+	return false
+}
+
+type DeleteOptions struct {
+	// Force allows deleting one of ProtectedRoots.
+	Force bool
+
+	// DryRun only returns the objects that would be deleted.
+	DryRun bool
+}
+
+// DeletePreview returns the object at remotePath and, for directories, every
+// object below it. Parents are listed before their children.
+func (w *Endpoint) DeletePreview(remotePath string) ([]models.WorkspaceObjectInfo, error) {
+	status, err := w.GetStatus(&models.WorkspaceGetStatusRequest{Path: remotePath})
+	if err != nil {
+		return nil, err
+	}
+
+	root := models.WorkspaceObjectInfo{
+		ObjectType: status.ObjectType,
+		Path:       status.Path,
+		Language:   status.Language,
+		ObjectId:   status.ObjectId,
+	}
+	if root.Path == "" {
+		root.Path = remotePath
+	}
+
+	return w.tree(root)
+}
+
+func (w *Endpoint) tree(obj models.WorkspaceObjectInfo) ([]models.WorkspaceObjectInfo, error) {
+	objects := []models.WorkspaceObjectInfo{obj}
+	if obj.ObjectType == nil || (*obj.ObjectType != models.DIRECTORY && *obj.ObjectType != models.REPO) {
+		return objects, nil
+	}
+
+	resp, err := w.List(&models.WorkspaceListRequest{Path: obj.Path})
+	if err != nil {
+		return nil, fmt.Errorf("listing %s: %v", obj.Path, err)
+	}
+
+	for _, child := range resp.Objects {
+		children, err := w.tree(child)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, children...)
+	}
+
+	return objects, nil
+}
+
+// DeleteRecursive deletes remotePath and everything below it, returning the
+// objects that were removed. Protected roots are refused with
+// ErrProtectedPath unless opts.Force is set.
+func (w *Endpoint) DeleteRecursive(remotePath string, opts DeleteOptions) ([]models.WorkspaceObjectInfo, error) {
+	if IsProtected(remotePath) && !opts.Force {
+		return nil, fmt.Errorf("%w: %s", ErrProtectedPath, remotePath)
+	}
+
+	objects, err := w.DeletePreview(remotePath)
+	if err != nil {
+		return nil, err
+	}
+	if opts.DryRun {
+		return objects, nil
+	}
+
+	err = w.Delete(&models.WorkspaceDeleteRequest{Path: remotePath, Recursive: true})
+	if err != nil {
+		return nil, err
+	}
+
+	return objects, nil
+}
+
+type MoveOptions struct {
+	// Overwrite replaces an existing object at the destination.
+	Overwrite bool
+
+	// Force allows moving one of ProtectedRoots.
+	Force bool
+}
+
+// Move renames src to dst. The Workspace API has no native move, so notebooks
+// are copied in DBC format, which keeps their results, files are copied raw and
+// the source is deleted once everything was copied. Libraries and repos cannot
+// be moved.
+func (w *Endpoint) Move(src string, dst string, opts MoveOptions) error {
+	src, dst = path.Clean(src), path.Clean(dst)
+	if src == dst {
+		return nil
+	}
+	if strings.HasPrefix(dst, src+"/") {
+		return fmt.Errorf("cannot move %s into itself", src)
+	}
+	if IsProtected(src) && !opts.Force {
+		return fmt.Errorf("%w: %s", ErrProtectedPath, src)
+	}
+
+	objects, err := w.DeletePreview(src)
+	if err != nil {
+		return err
+	}
+
+	for _, obj := range objects {
+		if obj.ObjectType != nil && (*obj.ObjectType == models.LIBRARY || *obj.ObjectType == models.REPO) {
+			return fmt.Errorf("cannot move %s: unsupported object type %s", obj.Path, *obj.ObjectType)
+		}
+	}
+
+	if !opts.Overwrite {
+		_, err := w.GetStatus(&models.WorkspaceGetStatusRequest{Path: dst})
+		if err == nil {
+			return fmt.Errorf("%s already exists", dst)
+		}
+		if !isNotExist(err) {
+			return err
+		}
+	}
+
+	for _, obj := range objects {
+		if obj.ObjectType == nil {
+			continue
+		}
+
+		target := dst + strings.TrimPrefix(obj.Path, src)
+		err := w.copyObject(obj, target, opts.Overwrite)
+		if err != nil {
+			return fmt.Errorf("moving %s to %s: %v", obj.Path, target, err)
+		}
+	}
+
+	return w.Delete(&models.WorkspaceDeleteRequest{Path: src, Recursive: true})
+}
+
+func (w *Endpoint) copyObject(obj models.WorkspaceObjectInfo, target string, overwrite bool) error {
+	switch *obj.ObjectType {
+	case models.DIRECTORY:
+		return w.Mkdirs(&models.WorkspaceMkdirsRequest{Path: target})
+	case models.NOTEBOOK:
+		format := models.DBC
+		resp, err := w.Export(&models.WorkspaceExportRequest{Path: obj.Path, Format: &format})
+		if err != nil {
+			return err
+		}
+
+		// DBC imports cannot overwrite, so replace the target explicitly.
+		if overwrite {
+			err := w.Delete(&models.WorkspaceDeleteRequest{Path: target})
+			if err != nil && !isNotExist(err) {
+				return err
+			}
+		}
+
+		return w.Import(&models.WorkspaceImportRequest{
+			Path:    target,
+			Format:  &format,
+			Content: resp.Content,
+		})
+	case models.FILE:
+		content, err := w.ExportFile(obj.Path)
+		if err != nil {
+			return err
+		}
+
+		return w.ImportFile(target, content, overwrite)
+	}
+
+	return fmt.Errorf("unsupported object type %s", *obj.ObjectType)
+}
+
+func isNotExist(err error) bool {
+	derr, ok := err.(client.Error)
+	return ok && derr.Code() == "RESOURCE_DOES_NOT_EXIST"
+}
@@ -0,0 +1,145 @@
+package workspace
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/tcz001/databricks-sdk-go/client"
+	"gopkg.in/h2non/gock.v1"
+)
+
+type BulkTestSuite struct {
+	suite.Suite
+	endpoint Endpoint
+}
+
+func (s *BulkTestSuite) SetupTest() {
+	domain := "server.com"
+	token := "token"
+	cl, err := client.NewClient(client.Options{Domain: &domain, Token: &token, RateLimitPerSecond: 100})
+	s.Require().NoError(err)
+
+	s.endpoint = Endpoint{Client: cl}
+}
+
+func (s *BulkTestSuite) TearDownTest() {
+	gock.OffAll()
+}
+
+func (s *BulkTestSuite) mockStatus(path string, objectType string) {
+	gock.New("https://server.com").
+		Get("^/api/2.0/workspace/get-status$").
+		BodyString(`"path":"` + path + `"}`).
+		Reply(200).
+		JSON(map[string]interface{}{"path": path, "object_type": objectType})
+}
+
+func (s *BulkTestSuite) mockList(path string, objects ...map[string]interface{}) {
+	gock.New("https://server.com").
+		Get("^/api/2.0/workspace/list$").
+		BodyString(`"path":"` + path + `"}`).
+		Reply(200).
+		JSON(map[string]interface{}{"objects": objects})
+}
+
+func (s *BulkTestSuite) mockTree() {
+	s.mockStatus("/Shared/old", "DIRECTORY")
+	s.mockList("/Shared/old",
+		map[string]interface{}{"path": "/Shared/old/a", "object_type": "NOTEBOOK", "language": "PYTHON"},
+		map[string]interface{}{"path": "/Shared/old/sub", "object_type": "DIRECTORY"},
+	)
+	s.mockList("/Shared/old/sub",
+		map[string]interface{}{"path": "/Shared/old/sub/data.csv", "object_type": "FILE"},
+	)
+}
+
+func (s *BulkTestSuite) TestDeleteRecursiveRefusesProtectedRoots() {
+	for _, p := range []string{"/", "/Users", "/Users/", "Shared", "/Repos/../Repos"} {
+		_, err := s.endpoint.DeleteRecursive(p, DeleteOptions{})
+		s.Require().Error(err, p)
+		s.Assert().True(errors.Is(err, ErrProtectedPath), p)
+	}
+
+	s.Assert().False(gock.HasUnmatchedRequest())
+}
+
+func (s *BulkTestSuite) TestDeleteRecursiveDryRunOnlyLists() {
+	s.mockTree()
+
+	objects, err := s.endpoint.DeleteRecursive("/Shared/old", DeleteOptions{DryRun: true})
+	s.Require().NoError(err)
+
+	paths := make([]string, len(objects))
+	for i, obj := range objects {
+		paths[i] = obj.Path
+	}
+	s.Assert().Equal([]string{"/Shared/old", "/Shared/old/a", "/Shared/old/sub", "/Shared/old/sub/data.csv"}, paths)
+	s.Assert().True(gock.IsDone())
+	s.Assert().False(gock.HasUnmatchedRequest())
+}
+
+func (s *BulkTestSuite) TestDeleteRecursive() {
+	s.mockTree()
+	gock.New("https://server.com").
+		Post("^/api/2.0/workspace/delete$").
+		BodyString(`"path":"/Shared/old","recursive":true`).
+		Reply(200).
+		JSON(map[string]interface{}{})
+
+	objects, err := s.endpoint.DeleteRecursive("/Shared/old", DeleteOptions{})
+	s.Require().NoError(err)
+
+	s.Assert().Len(objects, 4)
+	s.Assert().True(gock.IsDone())
+	s.Assert().False(gock.HasUnmatchedRequest())
+}
+
+func (s *BulkTestSuite) TestMoveRefusesExistingDestination() {
+	s.mockStatus("/Shared/a", "NOTEBOOK")
+	s.mockStatus("/Shared/b", "NOTEBOOK")
+
+	err := s.endpoint.Move("/Shared/a", "/Shared/b", MoveOptions{})
+	s.Require().Error(err)
+
+	s.Assert().Equal("/Shared/b already exists", err.Error())
+	s.Assert().True(gock.IsDone())
+	s.Assert().False(gock.HasUnmatchedRequest())
+}
+
+func (s *BulkTestSuite) TestMoveKeepsSourceWhenCopyFails() {
+	s.mockTree()
+	gock.New("https://server.com").
+		Post("^/api/2.0/workspace/mkdirs$").
+		BodyString(`"path":"/Shared/new"}`).
+		Reply(200).
+		JSON(map[string]interface{}{})
+	gock.New("https://server.com").
+		Get("^/api/2.0/workspace/export$").
+		BodyString(`"path":"/Shared/old/a"`).
+		Reply(500).
+		JSON(map[string]interface{}{"error_code": "INTERNAL_ERROR", "message": "boom"})
+
+	err := s.endpoint.Move("/Shared/old", "/Shared/new", MoveOptions{Overwrite: true})
+	s.Require().Error(err)
+
+	s.Assert().Contains(err.Error(), "moving /Shared/old/a to /Shared/new/a")
+	s.Assert().True(gock.IsDone())
+	s.Assert().False(gock.HasUnmatchedRequest())
+}
+
+func (s *BulkTestSuite) TestMoveRefusesProtectedRootsAndLibraries() {
+	err := s.endpoint.Move("/Shared", "/Archive", MoveOptions{})
+	s.Assert().True(errors.Is(err, ErrProtectedPath))
+
+	s.mockStatus("/Shared/lib", "LIBRARY")
+	err = s.endpoint.Move("/Shared/lib", "/Shared/lib2", MoveOptions{})
+	s.Require().Error(err)
+
+	s.Assert().Equal("cannot move /Shared/lib: unsupported object type LIBRARY", err.Error())
+	s.Assert().False(gock.HasUnmatchedRequest())
+}
+
+func TestBulkTestSuite(t *testing.T) {
+	suite.Run(t, new(BulkTestSuite))
+}
@@ -1,7 +1,11 @@
 package workspace
 
 import (
+	"encoding/base64"
 	"encoding/json"
+	"fmt"
+	"path"
+
 	"github.com/tcz001/databricks-sdk-go/client"
 	"github.com/tcz001/databricks-sdk-go/models"
 )
@@ -46,16 +50,20 @@ func (w *Endpoint) GetStatus(request *models.WorkspaceGetStatusRequest) (*models
 }
 
 func (w *Endpoint) Import(request *models.WorkspaceImportRequest) error {
-	if request.Language == nil {
-		defaultLanguage := models.SCALA
-		request.Language = &defaultLanguage
-	}
-
 	if request.Format == nil {
 		defaultFormat := models.SOURCE
 		request.Format = &defaultFormat
 	}
 
+	// The language is only meaningful for notebooks imported from source.
+	if request.Language == nil && *request.Format == models.SOURCE {
+		language, err := detectLanguage(request)
+		if err != nil {
+			return err
+		}
+		request.Language = language
+	}
+
 	_, err := w.Client.Query("POST", "workspace/import", request)
 	return err
 }
@@ -79,3 +87,19 @@ func (w *Endpoint) Mkdirs(request *models.WorkspaceMkdirsRequest) error {
 	_, err := w.Client.Query("POST", "workspace/mkdirs", request)
 	return err
 }
+
+// detectLanguage infers the language of a SOURCE import from the extension of
+// its path or, failing that, from the header of its content.
+func detectLanguage(request *models.WorkspaceImportRequest) (*models.WorkspaceLanguage, error) {
+	content, err := base64.StdEncoding.DecodeString(request.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	format, language, _, ok := DetectNotebook(path.Base(request.Path), content)
+	if !ok || format != models.SOURCE {
+		return nil, fmt.Errorf("cannot infer the language of %s, set Language", request.Path)
+	}
+
+	return language, nil
+}
@@ -0,0 +1,224 @@
+package workspace
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+
+	"github.com/tcz001/databricks-sdk-go/models"
+)
+
+const DefaultParallelism = 8
+
+var languageExtensions = map[models.WorkspaceLanguage]string{
+	models.PYTHON: ".py",
+	models.SCALA:  ".scala",
+	models.SQL:    ".sql",
+	models.R:      ".r",
+}
+
+// FileExtension returns the local file extension used for a notebook of the
+// given language exported in the given format.
+func FileExtension(language *models.WorkspaceLanguage, format models.WorkspaceExportFormat) string {
+	switch format {
+	case models.JUPYTER:
+		return ".ipynb"
+	case models.HTML:
+		return ".html"
+	case models.DBC:
+		return ".dbc"
+	}
+
+	if language == nil {
+		return ""
+	}
+
+	return languageExtensions[*language]
+}
+
+type ExportDirOptions struct {
+	Format models.WorkspaceExportFormat
+
+	// Parallelism bounds the number of concurrent requests. It defaults to
+	// DefaultParallelism.
+	Parallelism int
+}
+
+// ExportDir exports all notebooks and files below remotePath into localDir,
+// creating local directories mirroring the workspace hierarchy. Repos are
+// exported like directories.
+func (w *Endpoint) ExportDir(remotePath string, localDir string, format models.WorkspaceExportFormat) error {
+	return w.ExportDirWithOptions(remotePath, localDir, ExportDirOptions{Format: format})
+}
+
+func (w *Endpoint) ExportDirWithOptions(remotePath string, localDir string, opts ExportDirOptions) error {
+	if opts.Format == "" {
+		opts.Format = models.SOURCE
+	}
+	if opts.Parallelism <= 0 {
+		opts.Parallelism = DefaultParallelism
+	}
+
+	status, err := w.GetStatus(&models.WorkspaceGetStatusRequest{Path: remotePath})
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(localDir, 0755)
+	if err != nil {
+		return err
+	}
+
+	e := exporter{
+		endpoint: w,
+		format:   opts.Format,
+		sem:      make(chan struct{}, opts.Parallelism),
+	}
+
+	e.wg.Add(1)
+	if status.ObjectType != nil && (*status.ObjectType == models.DIRECTORY || *status.ObjectType == models.REPO) {
+		go e.walk(remotePath, localDir)
+	} else {
+		obj := models.WorkspaceObjectInfo{ObjectType: status.ObjectType, Path: status.Path, Language: status.Language}
+		go e.export(obj, filepath.Join(localDir, e.localName(obj)))
+	}
+	e.wg.Wait()
+
+	return e.err
+}
+
+type exporter struct {
+	endpoint *Endpoint
+	format   models.WorkspaceExportFormat
+	sem      chan struct{}
+	wg       sync.WaitGroup
+
+	mu  sync.Mutex
+	err error
+}
+
+func (e *exporter) walk(remotePath string, localDir string) {
+	defer e.wg.Done()
+
+	if e.failed() {
+		return
+	}
+
+	e.sem <- struct{}{}
+	resp, err := e.endpoint.List(&models.WorkspaceListRequest{Path: remotePath})
+	<-e.sem
+	if err != nil {
+		e.fail(fmt.Errorf("listing %s: %v", remotePath, err))
+		return
+	}
+
+	// A notebook named utils exported as utils.py would overwrite a file
+	// named utils.py in the same directory.
+	written := make(map[string]string, len(resp.Objects))
+	for _, obj := range resp.Objects {
+		if obj.ObjectType == nil {
+			continue
+		}
+
+		switch *obj.ObjectType {
+		case models.DIRECTORY, models.REPO, models.NOTEBOOK, models.FILE:
+		default:
+			continue
+		}
+
+		name := e.localName(obj)
+		if other, ok := written[name]; ok {
+			e.fail(fmt.Errorf("%s and %s both export to %s", other, obj.Path, filepath.Join(localDir, name)))
+			return
+		}
+		written[name] = obj.Path
+	}
+
+	for _, obj := range resp.Objects {
+		if obj.ObjectType == nil {
+			continue
+		}
+
+		switch *obj.ObjectType {
+		case models.DIRECTORY, models.REPO:
+			dir := filepath.Join(localDir, path.Base(obj.Path))
+			err := os.MkdirAll(dir, 0755)
+			if err != nil {
+				e.fail(err)
+				return
+			}
+
+			e.wg.Add(1)
+			go e.walk(obj.Path, dir)
+		case models.NOTEBOOK, models.FILE:
+			e.wg.Add(1)
+			go e.export(obj, filepath.Join(localDir, e.localName(obj)))
+		}
+	}
+}
+
+// exportFormat returns the format obj is exported in. Files are exported
+// as they are.
+func (e *exporter) exportFormat(obj models.WorkspaceObjectInfo) models.WorkspaceExportFormat {
+	if obj.ObjectType != nil && *obj.ObjectType == models.FILE {
+		return models.AUTO
+	}
+
+	return e.format
+}
+
+// localName returns the name obj is written to in its local directory.
+func (e *exporter) localName(obj models.WorkspaceObjectInfo) string {
+	name := path.Base(obj.Path)
+	if obj.ObjectType != nil && *obj.ObjectType == models.NOTEBOOK {
+		name += FileExtension(obj.Language, e.exportFormat(obj))
+	}
+
+	return name
+}
+
+func (e *exporter) export(obj models.WorkspaceObjectInfo, localPath string) {
+	defer e.wg.Done()
+
+	if e.failed() {
+		return
+	}
+
+	format := e.exportFormat(obj)
+	e.sem <- struct{}{}
+	resp, err := e.endpoint.Export(&models.WorkspaceExportRequest{Path: obj.Path, Format: &format})
+	<-e.sem
+	if err != nil {
+		e.fail(fmt.Errorf("exporting %s: %v", obj.Path, err))
+		return
+	}
+
+	content, err := base64.StdEncoding.DecodeString(resp.Content)
+	if err != nil {
+		e.fail(fmt.Errorf("decoding %s: %v", obj.Path, err))
+		return
+	}
+
+	err = ioutil.WriteFile(localPath, content, 0644)
+	if err != nil {
+		e.fail(err)
+	}
+}
+
+func (e *exporter) failed() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.err != nil
+}
+
+func (e *exporter) fail(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.err == nil {
+		e.err = err
+	}
+}
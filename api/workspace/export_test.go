@@ -0,0 +1,137 @@
+package workspace
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/tcz001/databricks-sdk-go/client"
+	"github.com/tcz001/databricks-sdk-go/models"
+	"gopkg.in/h2non/gock.v1"
+)
+
+type ExportTestSuite struct {
+	suite.Suite
+	endpoint Endpoint
+	dir      string
+}
+
+func (s *ExportTestSuite) SetupTest() {
+	domain := "server.com"
+	token := "token"
+	cl, err := client.NewClient(client.Options{Domain: &domain, Token: &token, RateLimitPerSecond: 100})
+	s.Require().NoError(err)
+
+	s.endpoint = Endpoint{Client: cl}
+
+	dir, err := ioutil.TempDir("", "workspace")
+	s.Require().NoError(err)
+	s.dir = dir
+
+	gock.New("https://server.com").
+		Get("^/api/2.0/workspace/get-status$").
+		Reply(200).
+		JSON(map[string]interface{}{"path": "/Src", "object_type": "DIRECTORY"})
+	gock.New("https://server.com").
+		Get("^/api/2.0/workspace/list$").
+		BodyString(`"path":"/Src"}`).
+		Reply(200).
+		JSON(map[string]interface{}{
+			"objects": []map[string]interface{}{
+				{"path": "/Src/a", "object_type": "NOTEBOOK", "language": "PYTHON"},
+				{"path": "/Src/data.csv", "object_type": "FILE"},
+				{"path": "/Src/sub", "object_type": "DIRECTORY"},
+				{"path": "/Src/lib", "object_type": "LIBRARY"},
+			},
+		})
+	gock.New("https://server.com").
+		Get("^/api/2.0/workspace/list$").
+		BodyString(`"path":"/Src/sub"}`).
+		Reply(200).
+		JSON(map[string]interface{}{
+			"objects": []map[string]interface{}{
+				{"path": "/Src/sub/b", "object_type": "NOTEBOOK", "language": "SQL"},
+			},
+		})
+}
+
+func (s *ExportTestSuite) TearDownTest() {
+	gock.OffAll()
+	os.RemoveAll(s.dir)
+}
+
+func (s *ExportTestSuite) mockExport(path string, format string, content string) {
+	gock.New("https://server.com").
+		Get("^/api/2.0/workspace/export$").
+		BodyString(`"path":"` + path + `","format":"` + format + `"`).
+		Reply(200).
+		JSON(map[string]interface{}{"content": base64.StdEncoding.EncodeToString([]byte(content))})
+}
+
+func (s *ExportTestSuite) readFile(name string) string {
+	content, err := ioutil.ReadFile(filepath.Join(s.dir, name))
+	s.Require().NoError(err)
+	return string(content)
+}
+
+func (s *ExportTestSuite) TestExportDir() {
+	s.mockExport("/Src/a", "SOURCE", "# Databricks notebook source\nprint(1)\n")
+	s.mockExport("/Src/data.csv", "AUTO", "x,y\n")
+	s.mockExport("/Src/sub/b", "SOURCE", "-- Databricks notebook source\nSELECT 1\n")
+
+	err := s.endpoint.ExportDirWithOptions("/Src", s.dir, ExportDirOptions{Parallelism: 2})
+	s.Require().NoError(err)
+
+	s.Assert().Equal("# Databricks notebook source\nprint(1)\n", s.readFile("a.py"))
+	s.Assert().Equal("x,y\n", s.readFile("data.csv"))
+	s.Assert().Equal("-- Databricks notebook source\nSELECT 1\n", s.readFile(filepath.Join("sub", "b.sql")))
+	s.Assert().NoFileExists(filepath.Join(s.dir, "lib"))
+	s.Assert().True(gock.IsDone())
+}
+
+func (s *ExportTestSuite) TestExportDirReturnsFirstError() {
+	s.mockExport("/Src/data.csv", "AUTO", "x,y\n")
+	s.mockExport("/Src/sub/b", "SOURCE", "-- Databricks notebook source\nSELECT 1\n")
+	gock.New("https://server.com").
+		Get("^/api/2.0/workspace/export$").
+		BodyString(`"path":"/Src/a"`).
+		Reply(500).
+		JSON(map[string]interface{}{"error_code": "INTERNAL_ERROR", "message": "boom"})
+
+	err := s.endpoint.ExportDirWithOptions("/Src", s.dir, ExportDirOptions{Parallelism: 1})
+	s.Require().Error(err)
+
+	s.Assert().Contains(err.Error(), "exporting /Src/a")
+	s.Assert().NoFileExists(filepath.Join(s.dir, "a.py"))
+}
+
+func (s *ExportTestSuite) TestExportDirRejectsNameCollisions() {
+	gock.OffAll()
+	gock.New("https://server.com").
+		Get("^/api/2.0/workspace/get-status$").
+		Reply(200).
+		JSON(map[string]interface{}{"path": "/Src", "object_type": "DIRECTORY"})
+	gock.New("https://server.com").
+		Get("^/api/2.0/workspace/list$").
+		Reply(200).
+		JSON(map[string]interface{}{
+			"objects": []map[string]interface{}{
+				{"path": "/Src/utils", "object_type": "NOTEBOOK", "language": "PYTHON"},
+				{"path": "/Src/utils.py", "object_type": "FILE"},
+			},
+		})
+
+	err := s.endpoint.ExportDir("/Src", s.dir, models.SOURCE)
+	s.Require().Error(err)
+
+	s.Assert().Equal("/Src/utils and /Src/utils.py both export to "+filepath.Join(s.dir, "utils.py"), err.Error())
+	s.Assert().NoFileExists(filepath.Join(s.dir, "utils.py"))
+	s.Assert().False(gock.HasUnmatchedRequest())
+}
+
+func TestExportTestSuite(t *testing.T) {
+	suite.Run(t, new(ExportTestSuite))
+}
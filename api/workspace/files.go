@@ -0,0 +1,30 @@
+package workspace
+
+import (
+	"encoding/base64"
+
+	"github.com/tcz001/databricks-sdk-go/models"
+)
+
+// ImportFile uploads content as a workspace file at path. Unlike Import in
+// AUTO format, the content is never converted into a notebook.
+func (w *Endpoint) ImportFile(path string, content []byte, overwrite bool) error {
+	format := models.RAW
+	return w.Import(&models.WorkspaceImportRequest{
+		Path:      path,
+		Format:    &format,
+		Content:   base64.StdEncoding.EncodeToString(content),
+		Overwrite: overwrite,
+	})
+}
+
+// ExportFile downloads the content of the workspace file at path.
+func (w *Endpoint) ExportFile(path string) ([]byte, error) {
+	format := models.AUTO
+	resp, err := w.Export(&models.WorkspaceExportRequest{Path: path, Format: &format})
+	if err != nil {
+		return nil, err
+	}
+
+	return base64.StdEncoding.DecodeString(resp.Content)
+}
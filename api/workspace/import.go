@@ -0,0 +1,337 @@
+package workspace
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/tcz001/databricks-sdk-go/models"
+)
+
+// notebookHeaders are only consulted when the extension does not identify the
+// language. Python and R notebooks share the "#" header, which is read as
+// Python.
+var notebookHeaders = []struct {
+	prefix   string
+	language models.WorkspaceLanguage
+}{
+	{"# Databricks notebook source", models.PYTHON},
+	{"// Databricks notebook source", models.SCALA},
+	{"-- Databricks notebook source", models.SQL},
+}
+
+func isHidden(name string) bool {
+	return strings.HasPrefix(name, ".")
+}
+
+// DetectNotebook infers the import format and language of a local file from
+// its extension or, failing that, from its "Databricks notebook source"
+// header. It also returns the notebook name to use in the workspace.
+func DetectNotebook(name string, content []byte) (
+	format models.WorkspaceExportFormat,
+	language *models.WorkspaceLanguage,
+	notebookName string,
+	ok bool,
+) {
+	ext := filepath.Ext(name)
+	lowerExt := strings.ToLower(ext)
+
+	if lowerExt == ".ipynb" {
+		return models.JUPYTER, nil, strings.TrimSuffix(name, ext), true
+	}
+
+	for l, e := range languageExtensions {
+		if e == lowerExt {
+			l := l
+			return models.SOURCE, &l, strings.TrimSuffix(name, ext), true
+		}
+	}
+
+	for _, h := range notebookHeaders {
+		if bytes.HasPrefix(content, []byte(h.prefix)) {
+			l := h.language
+			return models.SOURCE, &l, name, true
+		}
+	}
+
+	return "", nil, "", false
+}
+
+type ImportDirOptions struct {
+	// Delete removes remote notebooks and directories below remotePath that
+	// no longer exist locally.
+	Delete bool
+
+	// Files imports local files that are not notebooks as workspace files
+	// instead of ignoring them. Source files are then only imported as
+	// notebooks if they start with the "Databricks notebook source" header,
+	// so that plain modules such as utils.py keep their name and content.
+	Files bool
+
+	// Parallelism bounds the number of concurrent requests. It defaults to
+	// DefaultParallelism.
+	Parallelism int
+}
+
+type ImportDirResult struct {
+	Imported []string
+	Skipped  []string
+	Deleted  []string
+}
+
+// ImportDir imports all notebooks found below localDir into remotePath.
+func (w *Endpoint) ImportDir(localDir string, remotePath string) (*ImportDirResult, error) {
+	return w.ImportDirWithOptions(localDir, remotePath, ImportDirOptions{})
+}
+
+// ImportDirWithOptions imports all notebooks found below localDir into
+// remotePath, creating missing directories. Notebooks whose exported content
+// matches the local file are skipped.
+func (w *Endpoint) ImportDirWithOptions(localDir string, remotePath string, opts ImportDirOptions) (*ImportDirResult, error) {
+	if opts.Parallelism <= 0 {
+		opts.Parallelism = DefaultParallelism
+	}
+
+	im := importer{
+		endpoint: w,
+		sem:      make(chan struct{}, opts.Parallelism),
+		result:   &ImportDirResult{},
+	}
+
+	err := filepath.Walk(localDir, func(localPath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		if localPath != localDir && isHidden(info.Name()) {
+			return filepath.SkipDir
+		}
+
+		rel, err := filepath.Rel(localDir, localPath)
+		if err != nil {
+			return err
+		}
+
+		return im.dir(localPath, path.Join(remotePath, filepath.ToSlash(rel)), opts)
+	})
+
+	im.wg.Wait()
+
+	if err != nil {
+		return im.result, err
+	}
+	if im.err != nil {
+		return im.result, im.err
+	}
+
+	sort.Strings(im.result.Imported)
+	sort.Strings(im.result.Skipped)
+	sort.Strings(im.result.Deleted)
+
+	return im.result, nil
+}
+
+type importer struct {
+	endpoint *Endpoint
+	sem      chan struct{}
+	wg       sync.WaitGroup
+
+	mu     sync.Mutex
+	err    error
+	result *ImportDirResult
+}
+
+func (im *importer) dir(localDir string, remoteDir string, opts ImportDirOptions) error {
+	err := im.endpoint.Mkdirs(&models.WorkspaceMkdirsRequest{Path: remoteDir})
+	if err != nil {
+		return fmt.Errorf("creating %s: %v", remoteDir, err)
+	}
+
+	listing, err := im.endpoint.List(&models.WorkspaceListRequest{Path: remoteDir})
+	if err != nil {
+		return fmt.Errorf("listing %s: %v", remoteDir, err)
+	}
+
+	remote := make(map[string]models.WorkspaceObjectInfo, len(listing.Objects))
+	for _, obj := range listing.Objects {
+		remote[path.Base(obj.Path)] = obj
+	}
+
+	files, err := ioutil.ReadDir(localDir)
+	if err != nil {
+		return err
+	}
+
+	local := make(map[string]bool, len(files))
+	for _, f := range files {
+		if isHidden(f.Name()) {
+			continue
+		}
+		if f.IsDir() {
+			local[f.Name()] = true
+			continue
+		}
+
+		content, err := ioutil.ReadFile(filepath.Join(localDir, f.Name()))
+		if err != nil {
+			return err
+		}
+
+		objectType := models.NOTEBOOK
+		format, language, name, ok := DetectNotebook(f.Name(), content)
+		if ok && opts.Files && format == models.SOURCE && !hasNotebookHeader(content) {
+			ok = false
+		}
+		if ok && name == f.Name() {
+			language = headerLanguage(language, remote[name])
+		}
+		if !ok {
+			if !opts.Files {
+				continue
+			}
+			objectType, format, name = models.FILE, models.RAW, f.Name()
+		}
+		local[name] = true
+
+		request := models.WorkspaceImportRequest{
+			Path:      path.Join(remoteDir, name),
+			Format:    &format,
+			Language:  language,
+			Content:   base64.StdEncoding.EncodeToString(content),
+			Overwrite: true,
+		}
+
+		obj, exists := remote[name]
+		exists = exists && obj.ObjectType != nil && *obj.ObjectType == objectType
+
+		im.wg.Add(1)
+		go im.importObject(request, content, exists)
+	}
+
+	if opts.Delete {
+		for name, obj := range remote {
+			// Hidden local entries are never imported, so remote objects
+			// of the same name are left alone as well.
+			if local[name] || isHidden(name) || obj.ObjectType == nil {
+				continue
+			}
+			switch *obj.ObjectType {
+			case models.NOTEBOOK, models.DIRECTORY:
+			case models.FILE:
+				if !opts.Files {
+					continue
+				}
+			default:
+				continue
+			}
+
+			err := im.endpoint.Delete(&models.WorkspaceDeleteRequest{Path: obj.Path, Recursive: true})
+			if err != nil {
+				return fmt.Errorf("deleting %s: %v", obj.Path, err)
+			}
+
+			im.mu.Lock()
+			im.result.Deleted = append(im.result.Deleted, obj.Path)
+			im.mu.Unlock()
+		}
+	}
+
+	return nil
+}
+
+func (im *importer) importObject(request models.WorkspaceImportRequest, content []byte, exists bool) {
+	defer im.wg.Done()
+
+	im.sem <- struct{}{}
+	defer func() { <-im.sem }()
+
+	if exists && im.unchanged(request.Path, *request.Format, content) {
+		im.mu.Lock()
+		im.result.Skipped = append(im.result.Skipped, request.Path)
+		im.mu.Unlock()
+		return
+	}
+
+	err := im.endpoint.Import(&request)
+
+	im.mu.Lock()
+	defer im.mu.Unlock()
+	if err != nil {
+		if im.err == nil {
+			im.err = fmt.Errorf("importing %s: %v", request.Path, err)
+		}
+		return
+	}
+	im.result.Imported = append(im.result.Imported, request.Path)
+}
+
+func (im *importer) unchanged(remotePath string, format models.WorkspaceExportFormat, content []byte) bool {
+	// Files are imported RAW but exported in their AUTO format.
+	if format == models.RAW {
+		format = models.AUTO
+	}
+
+	resp, err := im.endpoint.Export(&models.WorkspaceExportRequest{Path: remotePath, Format: &format})
+	if err != nil {
+		return false
+	}
+
+	remote, err := base64.StdEncoding.DecodeString(resp.Content)
+	if err != nil {
+		return false
+	}
+
+	// Notebooks exported as SOURCE always start with a header that local
+	// files may omit.
+	if format == models.SOURCE {
+		remote = stripNotebookHeader(remote)
+		content = stripNotebookHeader(content)
+	}
+
+	return sha256.Sum256(remote) == sha256.Sum256(content)
+}
+
+// headerLanguage resolves a language detected from the shared "#" header
+// against the existing notebook, so that an R notebook without an extension
+// is not turned into Python.
+func headerLanguage(detected *models.WorkspaceLanguage, existing models.WorkspaceObjectInfo) *models.WorkspaceLanguage {
+	if existing.ObjectType == nil || *existing.ObjectType != models.NOTEBOOK || existing.Language == nil {
+		return detected
+	}
+	if detected != nil && *detected == models.PYTHON && *existing.Language == models.R {
+		return existing.Language
+	}
+
+	return detected
+}
+
+func hasNotebookHeader(content []byte) bool {
+	for _, h := range notebookHeaders {
+		if bytes.HasPrefix(content, []byte(h.prefix)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func stripNotebookHeader(content []byte) []byte {
+	for _, h := range notebookHeaders {
+		if bytes.HasPrefix(content, []byte(h.prefix)) {
+			content = content[len(h.prefix):]
+			return bytes.TrimPrefix(bytes.TrimPrefix(content, []byte("\r")), []byte("\n"))
+		}
+	}
+
+	return content
+}
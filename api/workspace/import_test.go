@@ -0,0 +1,244 @@
+package workspace
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/tcz001/databricks-sdk-go/client"
+	"github.com/tcz001/databricks-sdk-go/models"
+	"gopkg.in/h2non/gock.v1"
+)
+
+type ImportTestSuite struct {
+	suite.Suite
+	endpoint Endpoint
+	dir      string
+}
+
+func (s *ImportTestSuite) SetupTest() {
+	domain := "server.com"
+	token := "token"
+	cl, err := client.NewClient(client.Options{Domain: &domain, Token: &token, RateLimitPerSecond: 100})
+	s.Require().NoError(err)
+
+	s.endpoint = Endpoint{Client: cl}
+
+	dir, err := ioutil.TempDir("", "workspace")
+	s.Require().NoError(err)
+	s.dir = dir
+
+	gock.New("https://server.com").
+		Post("^/api/2.0/workspace/mkdirs$").
+		BodyString(`"path":"/Target"}`).
+		Reply(200).
+		JSON(map[string]interface{}{})
+}
+
+func (s *ImportTestSuite) TearDownTest() {
+	gock.OffAll()
+	os.RemoveAll(s.dir)
+}
+
+func (s *ImportTestSuite) writeFile(name string, content string) {
+	path := filepath.Join(s.dir, name)
+	s.Require().NoError(os.MkdirAll(filepath.Dir(path), 0755))
+	s.Require().NoError(ioutil.WriteFile(path, []byte(content), 0644))
+}
+
+func (s *ImportTestSuite) mockList(path string, objects ...map[string]interface{}) {
+	gock.New("https://server.com").
+		Get("^/api/2.0/workspace/list$").
+		BodyString(`"path":"` + path + `"}`).
+		Reply(200).
+		JSON(map[string]interface{}{"objects": objects})
+}
+
+func (s *ImportTestSuite) mockExport(path string, format string, content string) {
+	gock.New("https://server.com").
+		Get("^/api/2.0/workspace/export$").
+		BodyString(`"path":"` + path + `","format":"` + format + `"`).
+		Reply(200).
+		JSON(map[string]interface{}{"content": base64.StdEncoding.EncodeToString([]byte(content))})
+}
+
+func (s *ImportTestSuite) mockImport(path string) {
+	gock.New("https://server.com").
+		Post("^/api/2.0/workspace/import$").
+		BodyString(`"path":"` + path + `"`).
+		Reply(200).
+		JSON(map[string]interface{}{})
+}
+
+func (s *ImportTestSuite) TestImportDirSkipsUnchangedNotebooks() {
+	s.writeFile("a.py", "print(1)\n")
+	s.writeFile("b.sql", "-- Databricks notebook source\nSELECT 1\n")
+	s.writeFile("c.scala", "// Databricks notebook source\nval x = 1\n")
+
+	s.mockList("/Target",
+		map[string]interface{}{"path": "/Target/a", "object_type": "NOTEBOOK", "language": "PYTHON"},
+		map[string]interface{}{"path": "/Target/b", "object_type": "NOTEBOOK", "language": "SQL"},
+		map[string]interface{}{"path": "/Target/c", "object_type": "NOTEBOOK", "language": "SCALA"},
+	)
+	s.mockExport("/Target/a", "SOURCE", "# Databricks notebook source\nprint(1)\n")
+	s.mockExport("/Target/b", "SOURCE", "-- Databricks notebook source\nSELECT 1\n")
+	s.mockExport("/Target/c", "SOURCE", "// Databricks notebook source\nval x = 2\n")
+	s.mockImport("/Target/c")
+
+	result, err := s.endpoint.ImportDir(s.dir, "/Target")
+	s.Require().NoError(err)
+
+	s.Assert().Equal([]string{"/Target/c"}, result.Imported)
+	s.Assert().Equal([]string{"/Target/a", "/Target/b"}, result.Skipped)
+	s.Assert().True(gock.IsDone())
+	s.Assert().False(gock.HasUnmatchedRequest())
+}
+
+func (s *ImportTestSuite) TestImportDirDeletesRemovedObjects() {
+	s.writeFile("a.py", "print(1)\n")
+
+	s.mockList("/Target",
+		map[string]interface{}{"path": "/Target/old", "object_type": "NOTEBOOK", "language": "PYTHON"},
+		map[string]interface{}{"path": "/Target/gone", "object_type": "DIRECTORY"},
+		map[string]interface{}{"path": "/Target/data.csv", "object_type": "FILE"},
+		map[string]interface{}{"path": "/Target/lib", "object_type": "LIBRARY"},
+	)
+	s.mockImport("/Target/a")
+	s.writeFile(".env", "SECRET=1\n")
+	for _, path := range []string{"/Target/old", "/Target/gone"} {
+		gock.New("https://server.com").
+			Post("^/api/2.0/workspace/delete$").
+			BodyString(`"path":"` + path + `"`).
+			Reply(200).
+			JSON(map[string]interface{}{})
+	}
+
+	result, err := s.endpoint.ImportDirWithOptions(s.dir, "/Target", ImportDirOptions{Delete: true})
+	s.Require().NoError(err)
+
+	s.Assert().Equal([]string{"/Target/a"}, result.Imported)
+	s.Assert().Equal([]string{"/Target/gone", "/Target/old"}, result.Deleted)
+	s.Assert().True(gock.IsDone())
+	s.Assert().False(gock.HasUnmatchedRequest())
+}
+
+func (s *ImportTestSuite) TestImportDirReturnsListingError() {
+	s.writeFile("a.py", "print(1)\n")
+	s.writeFile(filepath.Join("sub", "b.py"), "print(2)\n")
+
+	s.mockList("/Target")
+	s.mockImport("/Target/a")
+	gock.New("https://server.com").
+		Post("^/api/2.0/workspace/mkdirs$").
+		BodyString(`"path":"/Target/sub"}`).
+		Reply(200).
+		JSON(map[string]interface{}{})
+	gock.New("https://server.com").
+		Get("^/api/2.0/workspace/list$").
+		BodyString(`"path":"/Target/sub"}`).
+		Reply(500).
+		JSON(map[string]interface{}{"error_code": "INTERNAL_ERROR", "message": "boom"})
+
+	_, err := s.endpoint.ImportDir(s.dir, "/Target")
+	s.Require().Error(err)
+
+	s.Assert().Contains(err.Error(), "listing /Target/sub")
+}
+
+func (s *ImportTestSuite) TestImportDirWithFilesKeepsPlainModules() {
+	s.writeFile("utils.py", "import os\n")
+	s.writeFile("main.py", "# Databricks notebook source\nimport utils\n")
+	s.writeFile("data.csv", "x,y\n")
+
+	s.mockList("/Target")
+	for _, body := range []string{
+		`"path":"/Target/utils.py","format":"RAW"`,
+		`"path":"/Target/main","format":"SOURCE","language":"PYTHON"`,
+		`"path":"/Target/data.csv","format":"RAW"`,
+	} {
+		gock.New("https://server.com").
+			Post("^/api/2.0/workspace/import$").
+			BodyString(body).
+			Reply(200).
+			JSON(map[string]interface{}{})
+	}
+
+	result, err := s.endpoint.ImportDirWithOptions(s.dir, "/Target", ImportDirOptions{Files: true})
+	s.Require().NoError(err)
+
+	s.Assert().Equal([]string{"/Target/data.csv", "/Target/main", "/Target/utils.py"}, result.Imported)
+	s.Assert().True(gock.IsDone())
+	s.Assert().False(gock.HasUnmatchedRequest())
+}
+
+func (s *ImportTestSuite) TestImportDirKeepsRNotebooksWithoutExtension() {
+	s.writeFile("analysis", "# Databricks notebook source\nx <- 1\n")
+	s.writeFile("script", "# Databricks notebook source\nx = 1\n")
+	s.writeFile("report.r", "# Databricks notebook source\nx <- 2\n")
+
+	s.mockList("/Target",
+		map[string]interface{}{"path": "/Target/analysis", "object_type": "NOTEBOOK", "language": "R"},
+	)
+	s.mockExport("/Target/analysis", "SOURCE", "# Databricks notebook source\nx <- 0\n")
+	for _, body := range []string{
+		`"path":"/Target/analysis","format":"SOURCE","language":"R"`,
+		`"path":"/Target/script","format":"SOURCE","language":"PYTHON"`,
+		`"path":"/Target/report","format":"SOURCE","language":"R"`,
+	} {
+		gock.New("https://server.com").
+			Post("^/api/2.0/workspace/import$").
+			BodyString(body).
+			Reply(200).
+			JSON(map[string]interface{}{})
+	}
+
+	result, err := s.endpoint.ImportDir(s.dir, "/Target")
+	s.Require().NoError(err)
+
+	s.Assert().Equal([]string{"/Target/analysis", "/Target/report", "/Target/script"}, result.Imported)
+	s.Assert().True(gock.IsDone())
+	s.Assert().False(gock.HasUnmatchedRequest())
+}
+
+func (s *ImportTestSuite) TestImportDetectsLanguage() {
+	gock.OffAll()
+	gock.New("https://server.com").
+		Post("^/api/2.0/workspace/import$").
+		BodyString(`"path":"/Target/etl","format":"SOURCE","language":"SQL"`).
+		Reply(200).
+		JSON(map[string]interface{}{})
+	gock.New("https://server.com").
+		Post("^/api/2.0/workspace/import$").
+		BodyString(`"path":"/Target/job.py","format":"SOURCE","language":"PYTHON"`).
+		Reply(200).
+		JSON(map[string]interface{}{})
+
+	err := s.endpoint.Import(&models.WorkspaceImportRequest{
+		Path:    "/Target/etl",
+		Content: base64.StdEncoding.EncodeToString([]byte("-- Databricks notebook source\nSELECT 1\n")),
+	})
+	s.Require().NoError(err)
+
+	err = s.endpoint.Import(&models.WorkspaceImportRequest{
+		Path:    "/Target/job.py",
+		Content: base64.StdEncoding.EncodeToString([]byte("print(1)\n")),
+	})
+	s.Require().NoError(err)
+
+	err = s.endpoint.Import(&models.WorkspaceImportRequest{
+		Path:    "/Target/unknown",
+		Content: base64.StdEncoding.EncodeToString([]byte("print(1)\n")),
+	})
+	s.Require().Error(err)
+
+	s.Assert().Equal("cannot infer the language of /Target/unknown, set Language", err.Error())
+	s.Assert().True(gock.IsDone())
+	s.Assert().False(gock.HasUnmatchedRequest())
+}
+
+func TestImportTestSuite(t *testing.T) {
+	suite.Run(t, new(ImportTestSuite))
+}
@@ -0,0 +1,77 @@
+package workspace
+
+import (
+	"os"
+	"path"
+	"time"
+
+	"github.com/tcz001/databricks-sdk-go/models"
+)
+
+// FileInfo describes a workspace object and implements os.FileInfo.
+type FileInfo struct {
+	models.WorkspaceObjectInfo
+}
+
+func NewFileInfo(obj models.WorkspaceObjectInfo) *FileInfo {
+	return &FileInfo{WorkspaceObjectInfo: obj}
+}
+
+func (f *FileInfo) Name() string {
+	return path.Base(f.Path)
+}
+
+func (f *FileInfo) Size() int64 {
+	return f.WorkspaceObjectInfo.Size
+}
+
+// Mode reports directories and repos as directories. All objects are
+// reported read-only.
+func (f *FileInfo) Mode() os.FileMode {
+	if f.IsDir() {
+		return os.ModeDir | 0555
+	}
+
+	return 0444
+}
+
+// ModTime returns the zero time when the service does not report the
+// modification time, as it does for directories.
+func (f *FileInfo) ModTime() time.Time {
+	if f.ModifiedAt == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, f.ModifiedAt*int64(time.Millisecond))
+}
+
+func (f *FileInfo) IsDir() bool {
+	if f.ObjectType == nil {
+		return false
+	}
+
+	return *f.ObjectType == models.DIRECTORY || *f.ObjectType == models.REPO
+}
+
+// Sys returns the underlying *models.WorkspaceObjectInfo.
+func (f *FileInfo) Sys() interface{} {
+	return &f.WorkspaceObjectInfo
+}
+
+// Stat returns the metadata of the object at path.
+func (w *Endpoint) Stat(path string) (*FileInfo, error) {
+	resp, err := w.GetStatus(&models.WorkspaceGetStatusRequest{Path: path})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewFileInfo(models.WorkspaceObjectInfo{
+		ObjectType: resp.ObjectType,
+		Path:       resp.Path,
+		Language:   resp.Language,
+		ObjectId:   resp.ObjectId,
+		CreatedAt:  resp.CreatedAt,
+		ModifiedAt: resp.ModifiedAt,
+		Size:       resp.Size,
+	}), nil
+}
@@ -0,0 +1,88 @@
+package workspace
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/tcz001/databricks-sdk-go/client"
+	"gopkg.in/h2non/gock.v1"
+)
+
+type StatTestSuite struct {
+	suite.Suite
+	endpoint Endpoint
+}
+
+func (s *StatTestSuite) SetupTest() {
+	domain := "server.com"
+	token := "token"
+	cl, err := client.NewClient(client.Options{Domain: &domain, Token: &token, RateLimitPerSecond: 100})
+	s.Require().NoError(err)
+
+	s.endpoint = Endpoint{Client: cl}
+}
+
+func (s *StatTestSuite) TearDownTest() {
+	gock.OffAll()
+}
+
+func (s *StatTestSuite) mockStatus(path string, status map[string]interface{}) {
+	gock.New("https://server.com").
+		Get("^/api/2.0/workspace/get-status$").
+		BodyString(`"path":"` + path + `"}`).
+		Reply(200).
+		JSON(status)
+}
+
+func (s *StatTestSuite) TestStatDirectory() {
+	s.mockStatus("/Shared/etl", map[string]interface{}{"path": "/Shared/etl", "object_type": "DIRECTORY", "object_id": 1})
+
+	info, err := s.endpoint.Stat("/Shared/etl")
+	s.Require().NoError(err)
+
+	s.Assert().Equal("etl", info.Name())
+	s.Assert().True(info.IsDir())
+	s.Assert().Equal(os.ModeDir|0555, info.Mode())
+	s.Assert().True(info.ModTime().IsZero())
+	s.Assert().True(gock.IsDone())
+}
+
+func (s *StatTestSuite) TestStatNotebook() {
+	s.mockStatus("/Shared/etl/load", map[string]interface{}{
+		"path":        "/Shared/etl/load",
+		"object_type": "NOTEBOOK",
+		"language":    "PYTHON",
+		"modified_at": 1600000000123,
+		"size":        42,
+	})
+
+	info, err := s.endpoint.Stat("/Shared/etl/load")
+	s.Require().NoError(err)
+
+	var fi os.FileInfo = info
+	s.Assert().Equal("load", fi.Name())
+	s.Assert().False(fi.IsDir())
+	s.Assert().Equal(os.FileMode(0444), fi.Mode())
+	s.Assert().Equal(int64(42), fi.Size())
+	s.Assert().Equal(time.Unix(1600000000, 123*int64(time.Millisecond)), fi.ModTime())
+	s.Assert().Equal("/Shared/etl/load", info.Path)
+	s.Assert().True(gock.IsDone())
+}
+
+func (s *StatTestSuite) TestStatMissingPath() {
+	gock.New("https://server.com").
+		Get("^/api/2.0/workspace/get-status$").
+		Reply(404).
+		JSON(map[string]interface{}{"error_code": "RESOURCE_DOES_NOT_EXIST", "message": "Path (/Shared/missing) doesn't exist."})
+
+	info, err := s.endpoint.Stat("/Shared/missing")
+
+	s.Assert().Error(err)
+	s.Assert().Nil(info)
+}
+
+func TestStatTestSuite(t *testing.T) {
+	suite.Run(t, new(StatTestSuite))
+}
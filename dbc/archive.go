@@ -0,0 +1,355 @@
+package dbc
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/tcz001/databricks-sdk-go/api/workspace"
+	"github.com/tcz001/databricks-sdk-go/models"
+	"github.com/tcz001/databricks-sdk-go/notebook"
+)
+
+var languageNames = map[models.WorkspaceLanguage]string{
+	models.PYTHON: "python",
+	models.SCALA:  "scala",
+	models.SQL:    "sql",
+	models.R:      "r",
+}
+
+type Command struct {
+	Version      string  `json:"version"`
+	OrigId       int64   `json:"origId"`
+	Guid         string  `json:"guid"`
+	Subtype      string  `json:"subtype"`
+	CommandType  string  `json:"commandType"`
+	Position     float64 `json:"position"`
+	Command      string  `json:"command"`
+	CommandTitle string  `json:"commandTitle"`
+}
+
+type Notebook struct {
+	// Path is the location of the notebook inside the archive, without the
+	// language extension.
+	Path string `json:"-"`
+
+	Version         string                 `json:"version"`
+	OrigId          int64                  `json:"origId"`
+	Name            string                 `json:"name"`
+	Language        string                 `json:"language"`
+	Commands        []Command              `json:"commands"`
+	Dashboards      []interface{}          `json:"dashboards"`
+	Guid            string                 `json:"guid"`
+	GlobalVars      map[string]interface{} `json:"globalVars"`
+	IPythonMetadata interface{}            `json:"iPythonMetadata"`
+	InputWidgets    map[string]interface{} `json:"inputWidgets"`
+}
+
+// WorkspaceLanguage returns the language of the notebook.
+func (n *Notebook) WorkspaceLanguage() (models.WorkspaceLanguage, bool) {
+	for l, name := range languageNames {
+		if name == n.Language {
+			return l, true
+		}
+	}
+
+	return "", false
+}
+
+// Notebook converts the commands into notebook cells.
+func (n *Notebook) Notebook() (*notebook.Notebook, error) {
+	language, ok := n.WorkspaceLanguage()
+	if !ok {
+		return nil, fmt.Errorf("unsupported language %q in %s", n.Language, n.Path)
+	}
+
+	commands := make([]Command, len(n.Commands))
+	copy(commands, n.Commands)
+	sort.SliceStable(commands, func(i, j int) bool { return commands[i].Position < commands[j].Position })
+
+	nb := notebook.Notebook{Language: language}
+	for _, c := range commands {
+		nb.Cells = append(nb.Cells, notebook.Cell{Source: c.Command})
+	}
+
+	return &nb, nil
+}
+
+// FromNotebook builds an archive notebook at the given archive path.
+func FromNotebook(archivePath string, nb *notebook.Notebook) (*Notebook, error) {
+	language, ok := languageNames[nb.Language]
+	if !ok {
+		return nil, fmt.Errorf("unsupported language %q", nb.Language)
+	}
+
+	guid, err := newGuid()
+	if err != nil {
+		return nil, err
+	}
+
+	result := Notebook{
+		Path:         archivePath,
+		Version:      "NotebookV1",
+		Name:         path.Base(archivePath),
+		Language:     language,
+		Commands:     make([]Command, 0, len(nb.Cells)),
+		Dashboards:   []interface{}{},
+		Guid:         guid,
+		GlobalVars:   map[string]interface{}{},
+		InputWidgets: map[string]interface{}{},
+	}
+
+	for i, cell := range nb.Cells {
+		guid, err := newGuid()
+		if err != nil {
+			return nil, err
+		}
+
+		result.Commands = append(result.Commands, Command{
+			Version:     "CommandV1",
+			Guid:        guid,
+			Subtype:     "command",
+			CommandType: "auto",
+			Position:    float64(i + 1),
+			Command:     cell.Source,
+		})
+	}
+
+	return &result, nil
+}
+
+type Archive struct {
+	Notebooks []Notebook
+}
+
+// Open reads a DBC archive from disk.
+func Open(file string) (*Archive, error) {
+	r, err := zip.OpenReader(file)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return read(&r.Reader)
+}
+
+// Read reads a DBC archive, e.g. the decoded content of a workspace export in
+// DBC format.
+func Read(r io.ReaderAt, size int64) (*Archive, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	return read(zr)
+}
+
+// Decode reads the archive returned by a workspace export in DBC format.
+func Decode(resp *models.WorkspaceExportResponse) (*Archive, error) {
+	content, err := base64.StdEncoding.DecodeString(resp.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	return Read(bytes.NewReader(content), int64(len(content)))
+}
+
+func read(zr *zip.Reader) (*Archive, error) {
+	archive := Archive{}
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+
+		ext := path.Ext(f.Name)
+		if !isLanguageExtension(ext) {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		content, err := ioutil.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		nb := Notebook{}
+		err = json.Unmarshal(content, &nb)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %v", f.Name, err)
+		}
+		nb.Path = strings.TrimSuffix(f.Name, ext)
+
+		archive.Notebooks = append(archive.Notebooks, nb)
+	}
+
+	return &archive, nil
+}
+
+func isLanguageExtension(ext string) bool {
+	for _, name := range languageNames {
+		if ext == "."+name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FromDir builds an archive from the notebooks found below dir. Notebooks are
+// placed in a top level folder named after dir.
+func FromDir(dir string) (*Archive, error) {
+	root := filepath.Base(filepath.Clean(dir))
+	archive := Archive{}
+
+	err := filepath.Walk(dir, func(localPath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(info.Name(), ".") && localPath != dir {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		content, err := ioutil.ReadFile(localPath)
+		if err != nil {
+			return err
+		}
+
+		format, language, name, ok := workspace.DetectNotebook(info.Name(), content)
+		if !ok {
+			return nil
+		}
+
+		var nb *notebook.Notebook
+		switch format {
+		case models.SOURCE:
+			nb, err = notebook.ParseSource(content, *language)
+		case models.JUPYTER:
+			nb, err = notebook.ParseJupyter(content)
+		default:
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("parsing %s: %v", localPath, err)
+		}
+
+		rel, err := filepath.Rel(dir, filepath.Dir(localPath))
+		if err != nil {
+			return err
+		}
+
+		dbcNotebook, err := FromNotebook(path.Join(root, filepath.ToSlash(rel), name), nb)
+		if err != nil {
+			return fmt.Errorf("converting %s: %v", localPath, err)
+		}
+
+		archive.Notebooks = append(archive.Notebooks, *dbcNotebook)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &archive, nil
+}
+
+// Extract writes every notebook in source format below dir, keeping the
+// folder hierarchy of the archive.
+func (a *Archive) Extract(dir string) error {
+	for _, n := range a.Notebooks {
+		nb, err := n.Notebook()
+		if err != nil {
+			return err
+		}
+
+		// Refuse entries escaping dir, e.g. "../../etc/profile".
+		rel := filepath.FromSlash(path.Clean("/" + n.Path))[1:]
+		if rel == "" || rel != filepath.FromSlash(n.Path) {
+			return fmt.Errorf("invalid notebook path %q in archive", n.Path)
+		}
+
+		file := filepath.Join(dir, rel) + workspace.FileExtension(&nb.Language, models.SOURCE)
+		err = os.MkdirAll(filepath.Dir(file), 0755)
+		if err != nil {
+			return err
+		}
+
+		err = ioutil.WriteFile(file, nb.Source(), 0644)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Write writes the archive as a zip file.
+func (a *Archive) Write(w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	for _, n := range a.Notebooks {
+		content, err := json.Marshal(n)
+		if err != nil {
+			return err
+		}
+
+		f, err := zw.Create(n.Path + "." + n.Language)
+		if err != nil {
+			return err
+		}
+
+		_, err = f.Write(content)
+		if err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// ImportRequest builds a request importing the whole archive below path.
+func (a *Archive) ImportRequest(path string) (*models.WorkspaceImportRequest, error) {
+	buf := bytes.Buffer{}
+	err := a.Write(&buf)
+	if err != nil {
+		return nil, err
+	}
+
+	format := models.DBC
+	return &models.WorkspaceImportRequest{
+		Path:    path,
+		Format:  &format,
+		Content: base64.StdEncoding.EncodeToString(buf.Bytes()),
+	}, nil
+}
+
+func newGuid() (string, error) {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", fmt.Errorf("generating guid: %v", err)
+	}
+
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
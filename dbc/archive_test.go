@@ -0,0 +1,116 @@
+package dbc
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/tcz001/databricks-sdk-go/models"
+)
+
+const pythonSource = `# Databricks notebook source
+import os
+
+# COMMAND ----------
+
+# MAGIC %md
+# MAGIC # Title
+`
+
+const sqlSource = `-- Databricks notebook source
+SELECT 1
+`
+
+type ArchiveTestSuite struct {
+	suite.Suite
+	dir string
+}
+
+func (s *ArchiveTestSuite) SetupTest() {
+	dir, err := ioutil.TempDir("", "dbc")
+	s.Require().NoError(err)
+	s.dir = dir
+}
+
+func (s *ArchiveTestSuite) TearDownTest() {
+	os.RemoveAll(s.dir)
+}
+
+func (s *ArchiveTestSuite) writeFile(name string, content string) {
+	file := filepath.Join(s.dir, name)
+	s.Require().NoError(os.MkdirAll(filepath.Dir(file), 0755))
+	s.Require().NoError(ioutil.WriteFile(file, []byte(content), 0644))
+}
+
+func (s *ArchiveTestSuite) readFile(name string) string {
+	content, err := ioutil.ReadFile(filepath.Join(s.dir, name))
+	s.Require().NoError(err)
+	return string(content)
+}
+
+func (s *ArchiveTestSuite) TestRoundTrip() {
+	s.writeFile(filepath.Join("project", "main.py"), pythonSource)
+	s.writeFile(filepath.Join("project", "sub", "query.sql"), sqlSource)
+	s.writeFile(filepath.Join("project", "README.txt"), "not a notebook")
+	s.writeFile(filepath.Join("project", ".hidden", "skipped.py"), pythonSource)
+
+	archive, err := FromDir(filepath.Join(s.dir, "project"))
+	s.Require().NoError(err)
+	s.Require().Len(archive.Notebooks, 2)
+
+	buf := bytes.Buffer{}
+	s.Require().NoError(archive.Write(&buf))
+
+	read, err := Read(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	s.Require().NoError(err)
+	s.Require().Len(read.Notebooks, 2)
+
+	paths := []string{read.Notebooks[0].Path, read.Notebooks[1].Path}
+	s.Assert().ElementsMatch([]string{"project/main", "project/sub/query"}, paths)
+	for i, n := range read.Notebooks {
+		s.Assert().Equal(archive.Notebooks[i].Guid, n.Guid)
+		s.Assert().NotEmpty(n.Guid)
+	}
+
+	language, ok := read.Notebooks[0].WorkspaceLanguage()
+	s.Assert().True(ok)
+	s.Assert().Equal(models.PYTHON, language)
+
+	s.Require().NoError(read.Extract(filepath.Join(s.dir, "out")))
+	s.Assert().Equal(pythonSource, s.readFile(filepath.Join("out", "project", "main.py")))
+	s.Assert().Equal(sqlSource, s.readFile(filepath.Join("out", "project", "sub", "query.sql")))
+}
+
+func (s *ArchiveTestSuite) TestExtractRejectsEscapingPaths() {
+	buf := bytes.Buffer{}
+	zw := zip.NewWriter(&buf)
+	f, err := zw.Create("../x.python")
+	s.Require().NoError(err)
+	_, err = f.Write([]byte(`{"version":"NotebookV1","name":"x","language":"python","commands":[]}`))
+	s.Require().NoError(err)
+	s.Require().NoError(zw.Close())
+
+	archive, err := Read(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	s.Require().NoError(err)
+	s.Require().Len(archive.Notebooks, 1)
+
+	out := filepath.Join(s.dir, "out")
+	err = archive.Extract(out)
+	s.Require().Error(err)
+
+	s.Assert().Equal(`invalid notebook path "../x" in archive`, err.Error())
+	s.Assert().NoFileExists(filepath.Join(s.dir, "x.py"))
+
+	for _, p := range []string{"/etc/x", "a/../../x", ""} {
+		archive := Archive{Notebooks: []Notebook{{Path: p, Language: "python"}}}
+		s.Assert().Error(archive.Extract(out), p)
+	}
+}
+
+func TestArchiveTestSuite(t *testing.T) {
+	suite.Run(t, new(ArchiveTestSuite))
+}
@@ -0,0 +1,78 @@
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/tcz001/databricks-sdk-go/models"
+	"gopkg.in/yaml.v3"
+)
+
+// Desired is the desired state of one cluster. Clusters without ClusterId
+// are looked up by Spec.ClusterName.
+type Desired struct {
+	ClusterId string                       `json:"cluster_id,omitempty"`
+	Spec      models.ClustersCreateRequest `json:"spec"`
+}
+
+// File is the layout of a desired-state file:
+//
+//	clusters:
+//	  - cluster_id: 0601-182128-dcbte59m
+//	    spec:
+//	      cluster_name: etl
+//	      spark_version: 10.4.x-scala2.12
+//	      node_type_id: m5.large
+//	      autoscale:
+//	        min_workers: 1
+//	        max_workers: 8
+type File struct {
+	Clusters []Desired `json:"clusters"`
+}
+
+// Load reads a desired-state file in YAML or JSON format.
+func Load(file string) ([]Desired, error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	desired, err := Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %v", file, err)
+	}
+
+	return desired, nil
+}
+
+// Parse parses a desired-state file in YAML or JSON format. Field names are
+// the snake case names used by the REST API.
+func Parse(content []byte) ([]Desired, error) {
+	// YAML is decoded generically and converted through JSON, so the json
+	// tags of the models apply to both formats.
+	var raw interface{}
+	err := yaml.Unmarshal(content, &raw)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	f := File{}
+	err = json.Unmarshal(data, &f)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, d := range f.Clusters {
+		if d.ClusterId == "" && d.Spec.ClusterName == "" {
+			return nil, fmt.Errorf("cluster %d has neither cluster_id nor spec.cluster_name", i)
+		}
+	}
+
+	return f.Clusters, nil
+}
@@ -0,0 +1,241 @@
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/tcz001/databricks-sdk-go/api/clusters"
+	"github.com/tcz001/databricks-sdk-go/models"
+)
+
+// serverFields are populated by the service and never part of a spec.
+var serverFields = []string{
+	"cluster_id", "creator_user_name", "pinned_by_user_name", "driver", "executors", "spark_context_id",
+	"jdbc_port", "cluster_source", "state", "state_message", "start_time", "terminated_time",
+	"last_state_loss_time", "last_activity_time", "cluster_memory_mb", "cluster_cores", "default_tags",
+	"cluster_log_status", "termination_reason",
+}
+
+// managedFields are reported as drift when they are set on the cluster but
+// missing from the spec. Other fields missing from the spec are assumed to
+// hold server defaults.
+var managedFields = map[string]bool{
+	"num_workers":      true,
+	"autoscale":        true,
+	"spark_conf":       true,
+	"spark_env_vars":   true,
+	"custom_tags":      true,
+	"ssh_public_keys":  true,
+	"init_scripts":     true,
+	"cluster_log_conf": true,
+	"docker_image":     true,
+	"single_user_name": true,
+	"policy_id":        true,
+}
+
+type FieldDiff = clusters.FieldDiff
+
+type ClusterDrift struct {
+	ClusterId   string      `json:"cluster_id,omitempty"`
+	ClusterName string      `json:"cluster_name,omitempty"`
+	Missing     bool        `json:"missing,omitempty"`
+	Diffs       []FieldDiff `json:"diffs,omitempty"`
+	Applied     bool        `json:"applied,omitempty"`
+	Error       string      `json:"error,omitempty"`
+}
+
+// Drifted reports whether the cluster is missing or differs from its spec.
+func (d ClusterDrift) Drifted() bool {
+	return d.Missing || len(d.Diffs) > 0
+}
+
+type Options struct {
+	// Apply edits drifted clusters to match their spec. Editing a running
+	// cluster restarts it.
+	Apply bool
+}
+
+// Detect compares every desired cluster with its current configuration.
+// Failures for one cluster are recorded in its ClusterDrift; the first one is
+// also returned.
+func Detect(endpoint *clusters.Endpoint, desired []Desired, opts Options) ([]ClusterDrift, error) {
+	var list *models.ClustersListResponse
+	var firstErr error
+	fail := func(d *ClusterDrift, err error) {
+		d.Error = err.Error()
+		if firstErr == nil {
+			firstErr = fmt.Errorf("cluster %s: %v", d.ClusterName, err)
+		}
+	}
+
+	result := make([]ClusterDrift, 0, len(desired))
+	for _, want := range desired {
+		d := ClusterDrift{ClusterId: want.ClusterId, ClusterName: want.Spec.ClusterName}
+
+		if d.ClusterId == "" {
+			if list == nil {
+				var err error
+				list, err = endpoint.List()
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			id, err := findByName(list.Clusters, want.Spec.ClusterName)
+			if err != nil {
+				fail(&d, err)
+				result = append(result, d)
+				continue
+			}
+			d.ClusterId = id
+		}
+		if d.ClusterId == "" {
+			d.Missing = true
+			result = append(result, d)
+			continue
+		}
+
+		cluster, err := endpoint.Get(&models.ClustersGetRequest{ClusterId: d.ClusterId})
+		if err != nil {
+			fail(&d, err)
+			result = append(result, d)
+			continue
+		}
+
+		d.Diffs, err = Diff(&want.Spec, cluster)
+		if err != nil {
+			fail(&d, err)
+		} else if opts.Apply && len(d.Diffs) > 0 {
+			err := apply(endpoint, d.ClusterId, &want.Spec)
+			if err != nil {
+				log.Printf("[ERROR] Unable to apply spec to cluster %s: %v", d.ClusterId, err)
+				fail(&d, err)
+			} else {
+				log.Printf("[INFO] Applied spec to cluster %s (%d fields)", d.ClusterId, len(d.Diffs))
+				d.Applied = true
+			}
+		}
+
+		result = append(result, d)
+	}
+
+	return result, firstErr
+}
+
+func findByName(clusterInfos []models.ClustersClusterInfo, name string) (string, error) {
+	id := ""
+	for _, c := range clusterInfos {
+		if c.ClusterName != name {
+			continue
+		}
+		if id != "" {
+			return "", fmt.Errorf("several clusters are named %q", name)
+		}
+		id = c.ClusterId
+	}
+
+	return id, nil
+}
+
+// Diff returns the fields of cluster that differ from spec, sorted by field,
+// after removing server-populated fields from cluster.
+func Diff(spec *models.ClustersCreateRequest, cluster *models.ClustersGetResponse) ([]FieldDiff, error) {
+	desired, err := toMap(spec)
+	if err != nil {
+		return nil, err
+	}
+	actual, err := toMap(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	normalize(desired, actual)
+
+	var diffs []FieldDiff
+	for field := range union(desired, actual) {
+		want, wantSet := desired[field]
+		got := actual[field]
+		if !wantSet && !managedFields[field] {
+			continue
+		}
+
+		diffs = append(diffs, clusters.DiffValue(field, want, got)...)
+	}
+
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Field < diffs[j].Field })
+	return diffs, nil
+}
+
+// normalize removes fields of actual that are populated by the service or
+// implied by desired.
+func normalize(desired map[string]interface{}, actual map[string]interface{}) {
+	defaultTags, _ := actual["default_tags"].(map[string]interface{})
+	for _, field := range serverFields {
+		delete(actual, field)
+	}
+
+	// Default tags are not always kept apart from custom tags.
+	if tags, ok := actual["custom_tags"].(map[string]interface{}); ok {
+		for k, v := range defaultTags {
+			if tags[k] == v {
+				delete(tags, k)
+			}
+		}
+		if len(tags) == 0 {
+			delete(actual, "custom_tags")
+		}
+	}
+
+	// Autoscaling clusters report their current size.
+	if _, ok := desired["autoscale"]; ok {
+		delete(actual, "num_workers")
+	}
+
+	// The driver defaults to the worker node type.
+	if _, ok := desired["driver_node_type_id"]; !ok && actual["driver_node_type_id"] == actual["node_type_id"] {
+		delete(actual, "driver_node_type_id")
+	}
+}
+
+// apply edits clusterId to match spec. The edit request carries every field
+// of the create request, so it is converted through JSON.
+func apply(endpoint *clusters.Endpoint, clusterId string, spec *models.ClustersCreateRequest) error {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return err
+	}
+
+	request := models.ClustersEditRequest{}
+	err = json.Unmarshal(data, &request)
+	if err != nil {
+		return err
+	}
+	request.ClusterId = clusterId
+
+	return endpoint.Edit(&request)
+}
+
+func toMap(v interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	m := map[string]interface{}{}
+	err = json.Unmarshal(data, &m)
+	return m, err
+}
+
+func union(a map[string]interface{}, b map[string]interface{}) map[string]bool {
+	keys := make(map[string]bool, len(a)+len(b))
+	for k := range a {
+		keys[k] = true
+	}
+	for k := range b {
+		keys[k] = true
+	}
+
+	return keys
+}
@@ -0,0 +1,164 @@
+package drift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/tcz001/databricks-sdk-go/api/clusters"
+	"github.com/tcz001/databricks-sdk-go/client"
+	"gopkg.in/h2non/gock.v1"
+)
+
+const desiredYAML = `
+clusters:
+  - cluster_id: etl-id
+    spec:
+      cluster_name: etl
+      spark_version: 10.4.x-scala2.12
+      node_type_id: m5.large
+      autoscale:
+        min_workers: 1
+        max_workers: 8
+      aws_attributes:
+        availability: SPOT_WITH_FALLBACK
+      custom_tags:
+        team: data
+  - spec:
+      cluster_name: adhoc
+      spark_version: 10.4.x-scala2.12
+      node_type_id: m5.large
+      num_workers: 2
+`
+
+type DriftTestSuite struct {
+	suite.Suite
+	endpoint clusters.Endpoint
+	desired  []Desired
+}
+
+func (s *DriftTestSuite) SetupTest() {
+	domain := "server.com"
+	token := "token"
+	cl, err := client.NewClient(client.Options{Domain: &domain, Token: &token, RateLimitPerSecond: 100})
+	s.Require().NoError(err)
+
+	s.endpoint = clusters.Endpoint{Client: cl}
+	s.desired, err = Parse([]byte(desiredYAML))
+	s.Require().NoError(err)
+
+	gock.New("https://server.com").
+		Get("^/api/2.0/clusters/list$").
+		Reply(200).
+		JSON(map[string]interface{}{
+			"clusters": []map[string]interface{}{
+				{"cluster_id": "etl-id", "cluster_name": "etl"},
+				{"cluster_id": "adhoc-id", "cluster_name": "adhoc"},
+			},
+		})
+	gock.New("https://server.com").
+		Get("^/api/2.0/clusters/get$").
+		BodyString(`"cluster_id":"etl-id"`).
+		Reply(200).
+		JSON(map[string]interface{}{
+			"cluster_id":          "etl-id",
+			"cluster_name":        "etl",
+			"spark_version":       "10.4.x-scala2.12",
+			"node_type_id":        "m5.large",
+			"driver_node_type_id": "m5.large",
+			"num_workers":         5,
+			"autoscale":           map[string]interface{}{"min_workers": 1, "max_workers": 4},
+			"aws_attributes":      map[string]interface{}{"availability": "SPOT_WITH_FALLBACK", "zone_id": "us-west-2a"},
+			"custom_tags":         map[string]string{"team": "data", "Vendor": "Databricks"},
+			"default_tags":        map[string]string{"Vendor": "Databricks"},
+			"cluster_source":      "UI",
+			"state":               "RUNNING",
+			"executors":           []map[string]interface{}{{"node_id": "n1"}},
+		})
+	gock.New("https://server.com").
+		Get("^/api/2.0/clusters/get$").
+		BodyString(`"cluster_id":"adhoc-id"`).
+		Reply(200).
+		JSON(map[string]interface{}{
+			"cluster_id":    "adhoc-id",
+			"cluster_name":  "adhoc",
+			"spark_version": "10.4.x-scala2.12",
+			"node_type_id":  "m5.large",
+			"num_workers":   2,
+			"spark_conf":    map[string]string{"spark.speculation": "true"},
+			"state":         "TERMINATED",
+		})
+}
+
+func (s *DriftTestSuite) TearDownTest() {
+	gock.Off()
+}
+
+func (s *DriftTestSuite) TestParse() {
+	s.Require().Len(s.desired, 2)
+	s.Assert().Equal("etl-id", s.desired[0].ClusterId)
+	s.Assert().Equal(int32(8), s.desired[0].Spec.Autoscale.MaxWorkers)
+	s.Assert().Equal("data", s.desired[0].Spec.CustomTags["team"])
+	s.Assert().Equal(int32(2), s.desired[1].Spec.NumWorkers)
+
+	desired, err := Parse([]byte(`{"clusters": [{"spec": {"cluster_name": "etl", "num_workers": 1}}]}`))
+	s.Require().NoError(err)
+	s.Assert().Equal("etl", desired[0].Spec.ClusterName)
+
+	_, err = Parse([]byte("clusters:\n  - spec:\n      num_workers: 1\n"))
+	s.Assert().Error(err)
+}
+
+func (s *DriftTestSuite) TestDetect() {
+	result, err := Detect(&s.endpoint, s.desired, Options{})
+	s.Require().NoError(err)
+	s.Require().Len(result, 2)
+
+	s.Assert().Equal("etl-id", result[0].ClusterId)
+	s.Assert().Equal([]FieldDiff{
+		{Field: "autoscale.max_workers", Desired: float64(8), Actual: float64(4)},
+	}, result[0].Diffs)
+
+	s.Assert().Equal("adhoc-id", result[1].ClusterId)
+	s.Assert().Equal([]FieldDiff{
+		{Field: "spark_conf", Desired: nil, Actual: map[string]interface{}{"spark.speculation": "true"}},
+	}, result[1].Diffs)
+	s.Assert().False(result[1].Applied)
+	s.Assert().True(gock.IsDone())
+}
+
+func (s *DriftTestSuite) TestDetectMissing() {
+	gock.Off()
+	gock.New("https://server.com").
+		Get("^/api/2.0/clusters/list$").
+		Reply(200).
+		JSON(map[string]interface{}{"clusters": []map[string]interface{}{}})
+
+	result, err := Detect(&s.endpoint, s.desired[1:], Options{})
+	s.Require().NoError(err)
+	s.Require().Len(result, 1)
+	s.Assert().True(result[0].Missing)
+	s.Assert().True(result[0].Drifted())
+}
+
+func (s *DriftTestSuite) TestApply() {
+	gock.New("https://server.com").
+		Post("^/api/2.0/clusters/edit$").
+		BodyString(`"cluster_id":"etl-id"`).
+		Reply(200).
+		JSON(map[string]interface{}{})
+	gock.New("https://server.com").
+		Post("^/api/2.0/clusters/edit$").
+		BodyString(`"cluster_id":"adhoc-id"`).
+		Reply(200).
+		JSON(map[string]interface{}{})
+
+	result, err := Detect(&s.endpoint, s.desired, Options{Apply: true})
+	s.Require().NoError(err)
+	s.Assert().True(result[0].Applied)
+	s.Assert().True(result[1].Applied)
+	s.Assert().True(gock.IsDone())
+}
+
+func TestDriftTestSuite(t *testing.T) {
+	suite.Run(t, new(DriftTestSuite))
+}
@@ -28,6 +28,7 @@ func main() {
 	endpoint := scim.Endpoint{
 		Client: cl,
 	}
+	_ = endpoint
 
 	//printServicePrincipals(listServicePrincipals(endpoint))
 	//printUserGroups(listGroups(endpoint))
@@ -222,8 +223,8 @@ func updateGroup(endpoint scim.Endpoint,id string,memberId string,memberName str
 		Ref:     updateMemberRef,
 	}
 
-	entitlement1 := models.Entitlements{Value: "allow-cluster-create"}
-	entitlement2 := models.Entitlements{Value: "allow-instance-pool-create"}
+	entitlement1 := models.Entitlements{Value: models.ALLOW_CLUSTER_CREATE}
+	entitlement2 := models.Entitlements{Value: models.ALLOW_INSTANCE_POOL_CREATE}
 	group := models.ScimGroup{
 		Entitlements: []models.Entitlements{entitlement1,entitlement2},
 		DisplayName:  groupName,
@@ -252,7 +253,7 @@ func listUsers(endpoint scim.Endpoint) *models.ListUserRequestScim {
 func createUser(endpoint scim.Endpoint,userName string,group string,) *models.ScimUser {
 	fmt.Println("Creating Users ")
 
-	entitlements := models.Entitlements{Value: "allow-cluster-create"}
+	entitlements := models.Entitlements{Value: models.ALLOW_CLUSTER_CREATE}
 	groups := models.Groups{
 		Display: "",
 		Value:   group,
@@ -299,8 +300,8 @@ func deleteUser(endpoint scim.Endpoint,id string)  {
 
 func updateUser(endpoint scim.Endpoint,id string,group string,userName string) *models.ScimUser  {
 	fmt.Println("Getting User  with id:",id)
-	entitlement1 := models.Entitlements{Value: "allow-cluster-create"}
-	entitlement2 := models.Entitlements{Value: "allow-instance-pool-create"}
+	entitlement1 := models.Entitlements{Value: models.ALLOW_CLUSTER_CREATE}
+	entitlement2 := models.Entitlements{Value: models.ALLOW_INSTANCE_POOL_CREATE}
 	groups := models.Groups{
 		Display: "",
 		Value:   group,
@@ -0,0 +1,16 @@
+module github.com/tcz001/databricks-sdk-go
+
+go 1.18
+
+require (
+	github.com/stretchr/testify v1.9.0
+	golang.org/x/time v0.9.0
+	gopkg.in/h2non/gock.v1 v1.1.2
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/h2non/parth v0.0.0-20190131123155-b4df798d6542 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+)
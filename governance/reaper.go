@@ -0,0 +1,328 @@
+package governance
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tcz001/databricks-sdk-go/api/clusters"
+	"github.com/tcz001/databricks-sdk-go/models"
+)
+
+type Reason string
+
+const (
+	// Idle clusters had no activity for longer than Options.IdleFor.
+	Idle Reason = "IDLE"
+
+	NoAutotermination      Reason = "NO_AUTOTERMINATION"
+	AutoterminationTooLong Reason = "AUTOTERMINATION_TOO_LONG"
+	MissingRequiredTags    Reason = "MISSING_REQUIRED_TAGS"
+)
+
+const (
+	DefaultIdleFor = 2 * time.Hour
+
+	// DefaultDbusPerCoreHour is a rough average of the DBUs consumed by one
+	// core in one hour, used to estimate reclaimed DBU hours.
+	DefaultDbusPerCoreHour = 0.25
+)
+
+type Options struct {
+	// IdleFor flags running clusters without activity for this duration. It
+	// defaults to DefaultIdleFor.
+	IdleFor time.Duration
+
+	// MaxAutoterminationMinutes flags clusters terminating after a longer
+	// inactivity period. Clusters without autotermination are always flagged.
+	MaxAutoterminationMinutes int32
+
+	// RequiredTags lists custom tags every cluster must carry.
+	RequiredTags []string
+
+	// AllowTags exempts clusters carrying one of the tags from termination.
+	// An empty value matches any value of the tag but "false", like
+	// clusters.SafeDelete does. It defaults to the
+	// clusters.DefaultProtectionTag tag. Pinned clusters are always exempt.
+	AllowTags map[string]string
+
+	// Sources lists the cluster sources to scan. It defaults to UI and API,
+	// as job clusters terminate with their run.
+	Sources []models.ClustersClusterSource
+
+	// Terminate terminates flagged clusters that are not allowlisted. The
+	// reaper only reports when it is false.
+	Terminate bool
+
+	// TerminateViolations also terminates clusters that are not idle but
+	// violate the autotermination or tag policies.
+	TerminateViolations bool
+
+	// DbusPerCoreHour defaults to DefaultDbusPerCoreHour.
+	DbusPerCoreHour float64
+
+	// Now is the reference time of the report. It defaults to time.Now().
+	Now time.Time
+}
+
+func (o Options) withDefaults() Options {
+	if o.IdleFor <= 0 {
+		o.IdleFor = DefaultIdleFor
+	}
+	if o.AllowTags == nil {
+		o.AllowTags = map[string]string{clusters.DefaultProtectionTag: ""}
+	}
+	if o.Sources == nil {
+		o.Sources = []models.ClustersClusterSource{models.UI, models.API}
+	}
+	if o.DbusPerCoreHour <= 0 {
+		o.DbusPerCoreHour = DefaultDbusPerCoreHour
+	}
+	if o.Now.IsZero() {
+		o.Now = time.Now()
+	}
+
+	return o
+}
+
+type Entry struct {
+	ClusterId       string                      `json:"cluster_id"`
+	ClusterName     string                      `json:"cluster_name,omitempty"`
+	CreatorUserName string                      `json:"creator_user_name,omitempty"`
+	State           models.ClustersClusterState `json:"state"`
+	LastActivity    time.Time                   `json:"last_activity"`
+	IdleFor         time.Duration               `json:"idle_for"`
+	Cores           float32                     `json:"cores"`
+	Reasons         []Reason                    `json:"reasons"`
+	Allowed         bool                        `json:"allowed"`
+	Terminated      bool                        `json:"terminated"`
+	Error           string                      `json:"error,omitempty"`
+
+	// EstimatedDbuHours estimates the DBU hours spent idle, i.e. reclaimed
+	// over a period of the same length by terminating the cluster.
+	EstimatedDbuHours float64 `json:"estimated_dbu_hours"`
+}
+
+type Report struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	DryRun      bool      `json:"dry_run"`
+	Entries     []Entry   `json:"entries"`
+
+	// ReclaimedDbuHours sums EstimatedDbuHours of the terminated clusters, or
+	// of the clusters that would be terminated in a dry run.
+	ReclaimedDbuHours float64 `json:"reclaimed_dbu_hours"`
+}
+
+// Reap scans all clusters, reports idle and policy-violating ones and, when
+// opts.Terminate is set, terminates them. Failing terminations are recorded in
+// the entries; the first one is also returned.
+func Reap(endpoint *clusters.Endpoint, opts Options) (*Report, error) {
+	resp, err := endpoint.List()
+	if err != nil {
+		return nil, err
+	}
+
+	opts = opts.withDefaults()
+	report := Build(resp.Clusters, opts)
+	if !opts.Terminate {
+		return report, nil
+	}
+
+	var firstErr error
+	for i := range report.Entries {
+		e := &report.Entries[i]
+		if !reapable(*e, opts) {
+			continue
+		}
+
+		// SafeDelete checks again that the cluster was not pinned or
+		// protected since it was listed.
+		err := endpoint.SafeDelete(&models.ClustersDeleteRequest{ClusterId: e.ClusterId}, clusters.SafeDeleteOptions{})
+		if errors.Is(err, clusters.ErrClusterProtected) {
+			log.Printf("[INFO] Skipped cluster %s: %v", e.ClusterId, err)
+			e.Allowed = true
+			continue
+		}
+		if err != nil {
+			log.Printf("[ERROR] Unable to terminate cluster %s: %v", e.ClusterId, err)
+			e.Error = err.Error()
+			if firstErr == nil {
+				firstErr = fmt.Errorf("terminating cluster %s: %v", e.ClusterId, err)
+			}
+			continue
+		}
+
+		log.Printf("[INFO] Terminated cluster %s (%s)", e.ClusterId, e.ClusterName)
+		e.Terminated = true
+	}
+
+	report.ReclaimedDbuHours = 0
+	for _, e := range report.Entries {
+		if e.Terminated {
+			report.ReclaimedDbuHours += e.EstimatedDbuHours
+		}
+	}
+
+	return report, firstErr
+}
+
+// Build reports the flagged clusters among clusters without terminating
+// them. Only running clusters from opts.Sources are considered.
+func Build(clusterInfos []models.ClustersClusterInfo, opts Options) *Report {
+	opts = opts.withDefaults()
+
+	sources := make(map[models.ClustersClusterSource]bool, len(opts.Sources))
+	for _, s := range opts.Sources {
+		sources[s] = true
+	}
+
+	report := Report{GeneratedAt: opts.Now, DryRun: !opts.Terminate, Entries: []Entry{}}
+	for _, c := range clusterInfos {
+		if c.State == nil || *c.State != models.RUNNING {
+			continue
+		}
+		if c.ClusterSource != nil && !sources[*c.ClusterSource] {
+			continue
+		}
+
+		entry := Entry{
+			ClusterId:       c.ClusterId,
+			ClusterName:     c.ClusterName,
+			CreatorUserName: c.CreatorUserName,
+			State:           *c.State,
+			Cores:           c.ClusterCores,
+			Allowed:         clusters.IsPinned(c) || allowed(c.CustomTags, opts.AllowTags),
+		}
+
+		lastActivity := c.LastActivityTime
+		if lastActivity == 0 {
+			lastActivity = c.StartTime
+		}
+		if lastActivity > 0 {
+			entry.LastActivity = fromMillis(lastActivity)
+			entry.IdleFor = opts.Now.Sub(entry.LastActivity)
+		}
+
+		if entry.IdleFor >= opts.IdleFor {
+			entry.Reasons = append(entry.Reasons, Idle)
+			entry.EstimatedDbuHours = float64(c.ClusterCores) * entry.IdleFor.Hours() * opts.DbusPerCoreHour
+		}
+		switch {
+		case c.AutoterminationMinutes == 0:
+			entry.Reasons = append(entry.Reasons, NoAutotermination)
+		case opts.MaxAutoterminationMinutes > 0 && c.AutoterminationMinutes > opts.MaxAutoterminationMinutes:
+			entry.Reasons = append(entry.Reasons, AutoterminationTooLong)
+		}
+		for _, tag := range opts.RequiredTags {
+			if _, ok := c.CustomTags[tag]; !ok {
+				entry.Reasons = append(entry.Reasons, MissingRequiredTags)
+				break
+			}
+		}
+
+		if len(entry.Reasons) == 0 {
+			continue
+		}
+
+		report.Entries = append(report.Entries, entry)
+		if reapable(entry, opts) {
+			report.ReclaimedDbuHours += entry.EstimatedDbuHours
+		}
+	}
+
+	sort.Slice(report.Entries, func(i, j int) bool {
+		return report.Entries[i].EstimatedDbuHours > report.Entries[j].EstimatedDbuHours
+	})
+
+	return &report
+}
+
+func reapable(e Entry, opts Options) bool {
+	if e.Allowed {
+		return false
+	}
+
+	for _, r := range e.Reasons {
+		if r == Idle || opts.TerminateViolations {
+			return true
+		}
+	}
+
+	return false
+}
+
+func allowed(tags map[string]string, allowTags map[string]string) bool {
+	for k, v := range allowTags {
+		value, ok := tags[k]
+		if !ok {
+			continue
+		}
+		if v == value || v == "" && !strings.EqualFold(value, "false") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+var csvHeader = []string{
+	"cluster_id", "cluster_name", "creator_user_name", "last_activity", "idle_hours", "cores", "reasons",
+	"allowed", "terminated", "estimated_dbu_hours", "error",
+}
+
+func (r *Report) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	err := writer.Write(csvHeader)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range r.Entries {
+		reasons := make([]string, len(e.Reasons))
+		for i, reason := range e.Reasons {
+			reasons[i] = string(reason)
+		}
+
+		lastActivity := ""
+		if !e.LastActivity.IsZero() {
+			lastActivity = e.LastActivity.Format(time.RFC3339)
+		}
+
+		err = writer.Write([]string{
+			e.ClusterId,
+			e.ClusterName,
+			e.CreatorUserName,
+			lastActivity,
+			fmt.Sprintf("%.1f", e.IdleFor.Hours()),
+			fmt.Sprintf("%g", e.Cores),
+			strings.Join(reasons, " "),
+			fmt.Sprintf("%t", e.Allowed),
+			fmt.Sprintf("%t", e.Terminated),
+			fmt.Sprintf("%.2f", e.EstimatedDbuHours),
+			e.Error,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func fromMillis(ms int64) time.Time {
+	return time.Unix(0, ms*int64(time.Millisecond)).UTC()
+}
@@ -0,0 +1,144 @@
+package governance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/tcz001/databricks-sdk-go/api/clusters"
+	"github.com/tcz001/databricks-sdk-go/client"
+	"gopkg.in/h2non/gock.v1"
+)
+
+type ReaperTestSuite struct {
+	suite.Suite
+	endpoint clusters.Endpoint
+	now      time.Time
+}
+
+func (s *ReaperTestSuite) SetupTest() {
+	domain := "server.com"
+	token := "token"
+	cl, err := client.NewClient(client.Options{Domain: &domain, Token: &token, RateLimitPerSecond: 100})
+	s.Require().NoError(err)
+
+	s.endpoint = clusters.Endpoint{Client: cl}
+	s.now = time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	millis := func(d time.Duration) int64 {
+		return s.now.Add(-d).UnixNano() / int64(time.Millisecond)
+	}
+
+	gock.New("https://server.com").
+		Get("^/api/2.0/clusters/list$").
+		Reply(200).
+		JSON(map[string]interface{}{
+			"clusters": []map[string]interface{}{
+				{"cluster_id": "idle", "state": "RUNNING", "cluster_source": "UI", "cluster_cores": 8,
+					"autotermination_minutes": 0, "last_activity_time": millis(10 * time.Hour)},
+				{"cluster_id": "protected", "state": "RUNNING", "cluster_source": "UI", "cluster_cores": 8,
+					"last_activity_time": millis(10 * time.Hour), "custom_tags": map[string]string{"protected": "true"}},
+				{"cluster_id": "busy", "state": "RUNNING", "cluster_source": "API", "cluster_cores": 4,
+					"autotermination_minutes": 60, "last_activity_time": millis(time.Minute)},
+				{"cluster_id": "job", "state": "RUNNING", "cluster_source": "JOB", "cluster_cores": 4,
+					"last_activity_time": millis(10 * time.Hour)},
+				{"cluster_id": "stopped", "state": "TERMINATED", "cluster_source": "UI"},
+			},
+		})
+}
+
+func (s *ReaperTestSuite) TearDownTest() {
+	gock.OffAll()
+}
+
+func (s *ReaperTestSuite) TestDryRunReportsWithoutTerminating() {
+	report, err := Reap(&s.endpoint, Options{Now: s.now})
+	s.Require().NoError(err)
+
+	s.Assert().True(report.DryRun)
+	s.Require().Len(report.Entries, 2)
+	s.Assert().Equal("idle", report.Entries[0].ClusterId)
+	s.Assert().Equal([]Reason{Idle, NoAutotermination}, report.Entries[0].Reasons)
+	s.Assert().Equal("protected", report.Entries[1].ClusterId)
+	s.Assert().True(report.Entries[1].Allowed)
+	s.Assert().InDelta(20.0, report.ReclaimedDbuHours, 0.001)
+	s.Assert().True(gock.IsDone())
+}
+
+func (s *ReaperTestSuite) TestTerminateSkipsAllowlistedClusters() {
+	gock.New("https://server.com").
+		Get("^/api/2.0/clusters/get$").
+		BodyString(`"cluster_id":"idle"`).
+		Reply(200).
+		JSON(map[string]interface{}{"cluster_id": "idle", "state": "RUNNING"})
+	gock.New("https://server.com").
+		Post("^/api/2.0/clusters/delete$").
+		BodyString(`"cluster_id":"idle"`).
+		Reply(200)
+
+	report, err := Reap(&s.endpoint, Options{
+		Now:          s.now,
+		Terminate:    true,
+		RequiredTags: []string{"team"},
+	})
+	s.Require().NoError(err)
+
+	terminated := map[string]bool{}
+	for _, e := range report.Entries {
+		terminated[e.ClusterId] = e.Terminated
+	}
+	s.Assert().Equal(map[string]bool{"idle": true, "protected": false, "busy": false}, terminated)
+	s.Assert().InDelta(20.0, report.ReclaimedDbuHours, 0.001)
+	s.Assert().True(gock.IsDone())
+}
+
+func (s *ReaperTestSuite) TestTerminateSkipsPinnedClusters() {
+	gock.Off()
+
+	idle := s.now.Add(-10*time.Hour).UnixNano() / int64(time.Millisecond)
+	gock.New("https://server.com").
+		Get("^/api/2.0/clusters/list$").
+		Reply(200).
+		JSON(map[string]interface{}{
+			"clusters": []map[string]interface{}{
+				{"cluster_id": "pinned", "state": "RUNNING", "cluster_source": "UI", "cluster_cores": 4,
+					"last_activity_time": idle, "pinned_by_user_name": "admin@example.com"},
+				{"cluster_id": "unprotected", "state": "RUNNING", "cluster_source": "UI", "cluster_cores": 4,
+					"last_activity_time": idle, "custom_tags": map[string]string{"protected": "false"}},
+				{"cluster_id": "pinned-since", "state": "RUNNING", "cluster_source": "UI", "cluster_cores": 4,
+					"last_activity_time": idle},
+			},
+		})
+	gock.New("https://server.com").
+		Get("^/api/2.0/clusters/get$").
+		BodyString(`"cluster_id":"unprotected"`).
+		Reply(200).
+		JSON(map[string]interface{}{"cluster_id": "unprotected", "custom_tags": map[string]string{"protected": "false"}})
+	gock.New("https://server.com").
+		Post("^/api/2.0/clusters/delete$").
+		BodyString(`"cluster_id":"unprotected"`).
+		Reply(200)
+	gock.New("https://server.com").
+		Get("^/api/2.0/clusters/get$").
+		BodyString(`"cluster_id":"pinned-since"`).
+		Reply(200).
+		JSON(map[string]interface{}{"cluster_id": "pinned-since", "pinned_by_user_name": "admin@example.com"})
+
+	report, err := Reap(&s.endpoint, Options{Now: s.now, Terminate: true})
+	s.Require().NoError(err)
+
+	allowed := map[string]bool{}
+	terminated := map[string]bool{}
+	for _, e := range report.Entries {
+		allowed[e.ClusterId] = e.Allowed
+		terminated[e.ClusterId] = e.Terminated
+	}
+	s.Assert().Equal(map[string]bool{"pinned": true, "unprotected": false, "pinned-since": true}, allowed)
+	s.Assert().Equal(map[string]bool{"pinned": false, "unprotected": true, "pinned-since": false}, terminated)
+	s.Assert().True(gock.IsDone())
+	s.Assert().False(gock.HasUnmatchedRequest())
+}
+
+func TestReaperSuite(t *testing.T) {
+	suite.Run(t, new(ReaperTestSuite))
+}
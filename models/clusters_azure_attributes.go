@@ -0,0 +1,18 @@
+/*
+ * Databricks
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 0.0.1
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+
+package models
+
+type ClustersAzureAttributes struct {
+	FirstOnDemand int32 `json:"first_on_demand,omitempty"`
+
+	Availability *ClustersAzureAvailability `json:"availability,omitempty"`
+
+	SpotBidMaxPrice float64 `json:"spot_bid_max_price,omitempty"`
+}
@@ -0,0 +1,19 @@
+/*
+ * Databricks
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 0.0.1
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+
+package models
+
+type ClustersAzureAvailability string
+
+// List of ClustersAzureAvailability
+const (
+	SPOT_AZURE               ClustersAzureAvailability = "SPOT_AZURE"
+	ON_DEMAND_AZURE          ClustersAzureAvailability = "ON_DEMAND_AZURE"
+	SPOT_WITH_FALLBACK_AZURE ClustersAzureAvailability = "SPOT_WITH_FALLBACK_AZURE"
+)
@@ -0,0 +1,20 @@
+/*
+ * Databricks
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 0.0.1
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+
+package models
+
+type ClustersClusterEvent struct {
+	ClusterId string `json:"cluster_id,omitempty"`
+
+	Timestamp int64 `json:"timestamp,omitempty"`
+
+	Type_ *ClustersEventType `json:"type,omitempty"`
+
+	Details *ClustersEventDetails `json:"details,omitempty"`
+}
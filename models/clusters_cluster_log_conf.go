@@ -11,4 +11,8 @@ package models
 
 type ClustersClusterLogConf struct {
 	Dbfs *ClustersDbfsStorageInfo `json:"dbfs,omitempty"`
+
+	S3 *ClustersS3StorageInfo `json:"s3,omitempty"`
+
+	Volumes *ClustersVolumesStorageInfo `json:"volumes,omitempty"`
 }
@@ -22,6 +22,10 @@ type ClustersCreateRequest struct {
 
 	AwsAttributes *ClustersAwsAttributes `json:"aws_attributes,omitempty"`
 
+	AzureAttributes *ClustersAzureAttributes `json:"azure_attributes,omitempty"`
+
+	GcpAttributes *ClustersGcpAttributes `json:"gcp_attributes,omitempty"`
+
 	NodeTypeId string `json:"node_type_id"`
 
 	DriverNodeTypeId string `json:"driver_node_type_id,omitempty"`
@@ -37,4 +41,18 @@ type ClustersCreateRequest struct {
 	AutoterminationMinutes int32 `json:"autotermination_minutes,omitempty"`
 
 	EnableElasticDisk bool `json:"enable_elastic_disk,omitempty"`
+
+	InitScripts []ClustersInitScriptInfo `json:"init_scripts,omitempty"`
+
+	DockerImage *ClustersDockerImage `json:"docker_image,omitempty"`
+
+	RuntimeEngine *ClustersRuntimeEngine `json:"runtime_engine,omitempty"`
+
+	DataSecurityMode *ClustersDataSecurityMode `json:"data_security_mode,omitempty"`
+
+	SingleUserName string `json:"single_user_name,omitempty"`
+
+	PolicyId string `json:"policy_id,omitempty"`
+
+	ApplyPolicyDefaultValues bool `json:"apply_policy_default_values,omitempty"`
 }
@@ -0,0 +1,22 @@
+/*
+ * Databricks
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 0.0.1
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+
+package models
+
+type ClustersDataSecurityMode string
+
+// List of ClustersDataSecurityMode
+const (
+	NONE               ClustersDataSecurityMode = "NONE"
+	SINGLE_USER        ClustersDataSecurityMode = "SINGLE_USER"
+	USER_ISOLATION     ClustersDataSecurityMode = "USER_ISOLATION"
+	LEGACY_TABLE_ACL   ClustersDataSecurityMode = "LEGACY_TABLE_ACL"
+	LEGACY_PASSTHROUGH ClustersDataSecurityMode = "LEGACY_PASSTHROUGH"
+	LEGACY_SINGLE_USER ClustersDataSecurityMode = "LEGACY_SINGLE_USER"
+)
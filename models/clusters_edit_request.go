@@ -24,6 +24,10 @@ type ClustersEditRequest struct {
 
 	AwsAttributes *ClustersAwsAttributes `json:"aws_attributes,omitempty"`
 
+	AzureAttributes *ClustersAzureAttributes `json:"azure_attributes,omitempty"`
+
+	GcpAttributes *ClustersGcpAttributes `json:"gcp_attributes,omitempty"`
+
 	NodeTypeId string `json:"node_type_id"`
 
 	DriverNodeTypeId string `json:"driver_node_type_id,omitempty"`
@@ -39,4 +43,18 @@ type ClustersEditRequest struct {
 	AutoterminationMinutes int32 `json:"autotermination_minutes,omitempty"`
 
 	EnableElasticDisk bool `json:"enable_elastic_disk,omitempty"`
+
+	InitScripts []ClustersInitScriptInfo `json:"init_scripts,omitempty"`
+
+	DockerImage *ClustersDockerImage `json:"docker_image,omitempty"`
+
+	RuntimeEngine *ClustersRuntimeEngine `json:"runtime_engine,omitempty"`
+
+	DataSecurityMode *ClustersDataSecurityMode `json:"data_security_mode,omitempty"`
+
+	SingleUserName string `json:"single_user_name,omitempty"`
+
+	PolicyId string `json:"policy_id,omitempty"`
+
+	ApplyPolicyDefaultValues bool `json:"apply_policy_default_values,omitempty"`
 }
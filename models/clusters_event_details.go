@@ -0,0 +1,38 @@
+/*
+ * Databricks
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 0.0.1
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+
+package models
+
+type ClustersEventDetails struct {
+	CurrentNumWorkers int32 `json:"current_num_workers,omitempty"`
+
+	TargetNumWorkers int32 `json:"target_num_workers,omitempty"`
+
+	PreviousClusterSize *ClustersClusterSize `json:"previous_cluster_size,omitempty"`
+
+	ClusterSize *ClustersClusterSize `json:"cluster_size,omitempty"`
+
+	Cause string `json:"cause,omitempty"`
+
+	Reason *ClustersTerminationReason `json:"reason,omitempty"`
+
+	User string `json:"user,omitempty"`
+
+	PreviousDiskSize int64 `json:"previous_disk_size,omitempty"`
+
+	DiskSize int64 `json:"disk_size,omitempty"`
+
+	FreeSpace int64 `json:"free_space,omitempty"`
+
+	DidNotExpandReason string `json:"did_not_expand_reason,omitempty"`
+
+	InstanceId string `json:"instance_id,omitempty"`
+
+	JobRunName string `json:"job_run_name,omitempty"`
+}
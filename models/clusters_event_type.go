@@ -0,0 +1,40 @@
+/*
+ * Databricks
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 0.0.1
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+
+package models
+
+type ClustersEventType string
+
+// List of ClustersEventType
+const (
+	CREATING                 ClustersEventType = "CREATING"
+	DID_NOT_EXPAND_DISK      ClustersEventType = "DID_NOT_EXPAND_DISK"
+	EXPANDED_DISK            ClustersEventType = "EXPANDED_DISK"
+	FAILED_TO_EXPAND_DISK    ClustersEventType = "FAILED_TO_EXPAND_DISK"
+	INIT_SCRIPTS_STARTING    ClustersEventType = "INIT_SCRIPTS_STARTING"
+	INIT_SCRIPTS_FINISHED    ClustersEventType = "INIT_SCRIPTS_FINISHED"
+	STARTING                 ClustersEventType = "STARTING"
+	RESTARTING_              ClustersEventType = "RESTARTING"
+	TERMINATING_             ClustersEventType = "TERMINATING"
+	EDITED                   ClustersEventType = "EDITED"
+	RUNNING_                 ClustersEventType = "RUNNING"
+	RESIZING_                ClustersEventType = "RESIZING"
+	UPSIZE_COMPLETED         ClustersEventType = "UPSIZE_COMPLETED"
+	NODES_LOST               ClustersEventType = "NODES_LOST"
+	DRIVER_HEALTHY           ClustersEventType = "DRIVER_HEALTHY"
+	DRIVER_UNAVAILABLE       ClustersEventType = "DRIVER_UNAVAILABLE"
+	SPARK_EXCEPTION          ClustersEventType = "SPARK_EXCEPTION"
+	DRIVER_NOT_RESPONDING    ClustersEventType = "DRIVER_NOT_RESPONDING"
+	DBFS_DOWN                ClustersEventType = "DBFS_DOWN"
+	METASTORE_DOWN           ClustersEventType = "METASTORE_DOWN"
+	AUTOSCALING_STATS_REPORT ClustersEventType = "AUTOSCALING_STATS_REPORT"
+	NODE_BLACKLISTED         ClustersEventType = "NODE_BLACKLISTED"
+	PINNED                   ClustersEventType = "PINNED"
+	UNPINNED                 ClustersEventType = "UNPINNED"
+)
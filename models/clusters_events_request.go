@@ -0,0 +1,26 @@
+/*
+ * Databricks
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 0.0.1
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+
+package models
+
+type ClustersEventsRequest struct {
+	ClusterId string `json:"cluster_id"`
+
+	StartTime int64 `json:"start_time,omitempty"`
+
+	EndTime int64 `json:"end_time,omitempty"`
+
+	Order *ClustersListOrder `json:"order,omitempty"`
+
+	EventTypes []ClustersEventType `json:"event_types,omitempty"`
+
+	Offset int64 `json:"offset,omitempty"`
+
+	Limit int64 `json:"limit,omitempty"`
+}
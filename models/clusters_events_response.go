@@ -0,0 +1,18 @@
+/*
+ * Databricks
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 0.0.1
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+
+package models
+
+type ClustersEventsResponse struct {
+	Events []ClustersClusterEvent `json:"events,omitempty"`
+
+	NextPage *ClustersEventsRequest `json:"next_page,omitempty"`
+
+	TotalCount int64 `json:"total_count,omitempty"`
+}
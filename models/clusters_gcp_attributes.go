@@ -0,0 +1,20 @@
+/*
+ * Databricks
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 0.0.1
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+
+package models
+
+type ClustersGcpAttributes struct {
+	GoogleServiceAccount string `json:"google_service_account,omitempty"`
+
+	Availability *ClustersGcpAvailability `json:"availability,omitempty"`
+
+	BootDiskSize int32 `json:"boot_disk_size,omitempty"`
+
+	LocalSsdCount int32 `json:"local_ssd_count,omitempty"`
+}
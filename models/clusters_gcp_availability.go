@@ -0,0 +1,19 @@
+/*
+ * Databricks
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 0.0.1
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+
+package models
+
+type ClustersGcpAvailability string
+
+// List of ClustersGcpAvailability
+const (
+	PREEMPTIBLE_GCP               ClustersGcpAvailability = "PREEMPTIBLE_GCP"
+	ON_DEMAND_GCP                 ClustersGcpAvailability = "ON_DEMAND_GCP"
+	PREEMPTIBLE_WITH_FALLBACK_GCP ClustersGcpAvailability = "PREEMPTIBLE_WITH_FALLBACK_GCP"
+)
@@ -18,6 +18,8 @@ type ClustersGetResponse struct {
 
 	CreatorUserName string `json:"creator_user_name,omitempty"`
 
+	PinnedByUserName string `json:"pinned_by_user_name,omitempty"`
+
 	Driver *ClustersSparkNode `json:"driver,omitempty"`
 
 	Executors []ClustersSparkNode `json:"executors,omitempty"`
@@ -34,6 +36,10 @@ type ClustersGetResponse struct {
 
 	AwsAttributes *ClustersAwsAttributes `json:"aws_attributes,omitempty"`
 
+	AzureAttributes *ClustersAzureAttributes `json:"azure_attributes,omitempty"`
+
+	GcpAttributes *ClustersGcpAttributes `json:"gcp_attributes,omitempty"`
+
 	NodeTypeId string `json:"node_type_id,omitempty"`
 
 	DriverNodeTypeId string `json:"driver_node_type_id,omitempty"`
@@ -50,6 +56,20 @@ type ClustersGetResponse struct {
 
 	EnableElasticDisk bool `json:"enable_elastic_disk,omitempty"`
 
+	InitScripts []ClustersInitScriptInfo `json:"init_scripts,omitempty"`
+
+	DockerImage *ClustersDockerImage `json:"docker_image,omitempty"`
+
+	RuntimeEngine *ClustersRuntimeEngine `json:"runtime_engine,omitempty"`
+
+	DataSecurityMode *ClustersDataSecurityMode `json:"data_security_mode,omitempty"`
+
+	SingleUserName string `json:"single_user_name,omitempty"`
+
+	PolicyId string `json:"policy_id,omitempty"`
+
+	ApplyPolicyDefaultValues bool `json:"apply_policy_default_values,omitempty"`
+
 	ClusterSource *ClustersClusterSource `json:"cluster_source,omitempty"`
 
 	State *ClustersClusterState `json:"state,omitempty"`
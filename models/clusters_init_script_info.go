@@ -0,0 +1,20 @@
+/*
+ * Databricks
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 0.0.1
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+
+package models
+
+type ClustersInitScriptInfo struct {
+	Dbfs *ClustersDbfsStorageInfo `json:"dbfs,omitempty"`
+
+	S3 *ClustersS3StorageInfo `json:"s3,omitempty"`
+
+	Workspace *ClustersWorkspaceStorageInfo `json:"workspace,omitempty"`
+
+	Volumes *ClustersVolumesStorageInfo `json:"volumes,omitempty"`
+}
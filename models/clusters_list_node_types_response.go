@@ -0,0 +1,14 @@
+/*
+ * Databricks
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 0.0.1
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+
+package models
+
+type ClustersListNodeTypesResponse struct {
+	NodeTypes []ClustersNodeType `json:"node_types,omitempty"`
+}
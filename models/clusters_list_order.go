@@ -0,0 +1,18 @@
+/*
+ * Databricks
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 0.0.1
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+
+package models
+
+type ClustersListOrder string
+
+// List of ClustersListOrder
+const (
+	DESC ClustersListOrder = "DESC"
+	ASC  ClustersListOrder = "ASC"
+)
@@ -0,0 +1,16 @@
+/*
+ * Databricks
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 0.0.1
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+
+package models
+
+type ClustersListZonesResponse struct {
+	Zones []string `json:"zones,omitempty"`
+
+	DefaultZone string `json:"default_zone,omitempty"`
+}
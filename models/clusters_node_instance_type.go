@@ -0,0 +1,22 @@
+/*
+ * Databricks
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 0.0.1
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+
+package models
+
+type ClustersNodeInstanceType struct {
+	InstanceTypeId string `json:"instance_type_id,omitempty"`
+
+	LocalDisks int32 `json:"local_disks,omitempty"`
+
+	LocalDiskSizeGb int32 `json:"local_disk_size_gb,omitempty"`
+
+	LocalNvmeDisks int32 `json:"local_nvme_disks,omitempty"`
+
+	LocalNvmeDiskSizeGb int32 `json:"local_nvme_disk_size_gb,omitempty"`
+}
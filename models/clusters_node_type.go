@@ -0,0 +1,40 @@
+/*
+ * Databricks
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 0.0.1
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+
+package models
+
+type ClustersNodeType struct {
+	NodeTypeId string `json:"node_type_id,omitempty"`
+
+	MemoryMb int32 `json:"memory_mb,omitempty"`
+
+	NumCores float32 `json:"num_cores,omitempty"`
+
+	NumGpus int32 `json:"num_gpus,omitempty"`
+
+	Description string `json:"description,omitempty"`
+
+	InstanceTypeId string `json:"instance_type_id,omitempty"`
+
+	Category string `json:"category,omitempty"`
+
+	IsDeprecated bool `json:"is_deprecated,omitempty"`
+
+	IsHidden bool `json:"is_hidden,omitempty"`
+
+	SupportEbsVolumes bool `json:"support_ebs_volumes,omitempty"`
+
+	SupportClusterTags bool `json:"support_cluster_tags,omitempty"`
+
+	PhotonWorkerCapable bool `json:"photon_worker_capable,omitempty"`
+
+	PhotonDriverCapable bool `json:"photon_driver_capable,omitempty"`
+
+	NodeInstanceType *ClustersNodeInstanceType `json:"node_instance_type,omitempty"`
+}
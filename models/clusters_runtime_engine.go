@@ -0,0 +1,18 @@
+/*
+ * Databricks
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 0.0.1
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+
+package models
+
+type ClustersRuntimeEngine string
+
+// List of ClustersRuntimeEngine
+const (
+	STANDARD ClustersRuntimeEngine = "STANDARD"
+	PHOTON   ClustersRuntimeEngine = "PHOTON"
+)
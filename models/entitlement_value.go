@@ -0,0 +1,20 @@
+/*
+ * Databricks
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 0.0.1
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+
+package models
+
+type EntitlementValue = string
+
+// List of EntitlementValue
+const (
+	WORKSPACE_ACCESS           EntitlementValue = "workspace-access"
+	DATABRICKS_SQL_ACCESS      EntitlementValue = "databricks-sql-access"
+	ALLOW_CLUSTER_CREATE       EntitlementValue = "allow-cluster-create"
+	ALLOW_INSTANCE_POOL_CREATE EntitlementValue = "allow-instance-pool-create"
+)
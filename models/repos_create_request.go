@@ -0,0 +1,20 @@
+/*
+ * Databricks
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 0.0.1
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+
+package models
+
+type ReposCreateRequest struct {
+	Url string `json:"url"`
+
+	Provider string `json:"provider"`
+
+	Path string `json:"path,omitempty"`
+
+	SparseCheckout *ReposSparseCheckout `json:"sparse_checkout,omitempty"`
+}
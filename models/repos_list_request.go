@@ -0,0 +1,16 @@
+/*
+ * Databricks
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 0.0.1
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+
+package models
+
+type ReposListRequest struct {
+	PathPrefix string `json:"path_prefix,omitempty"`
+
+	NextPageToken string `json:"next_page_token,omitempty"`
+}
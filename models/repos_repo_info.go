@@ -0,0 +1,26 @@
+/*
+ * Databricks
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 0.0.1
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+
+package models
+
+type ReposRepoInfo struct {
+	Id int64 `json:"id,omitempty"`
+
+	Path string `json:"path,omitempty"`
+
+	Url string `json:"url,omitempty"`
+
+	Provider string `json:"provider,omitempty"`
+
+	Branch string `json:"branch,omitempty"`
+
+	HeadCommitId string `json:"head_commit_id,omitempty"`
+
+	SparseCheckout *ReposSparseCheckout `json:"sparse_checkout,omitempty"`
+}
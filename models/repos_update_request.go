@@ -0,0 +1,18 @@
+/*
+ * Databricks
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 0.0.1
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+
+package models
+
+type ReposUpdateRequest struct {
+	Branch string `json:"branch,omitempty"`
+
+	Tag string `json:"tag,omitempty"`
+
+	SparseCheckout *ReposSparseCheckout `json:"sparse_checkout,omitempty"`
+}
@@ -10,6 +10,7 @@
 package models
 
 type ScimGroup struct {
+	Schemas      []string       `json:"schemas,omitempty"`
 	Entitlements []Entitlements `json:"entitlements,omitempty"`
 	DisplayName  string         `json:"displayName,omitempty"`
 	Members      []ScimMember   `json:"members,omitempty"`
@@ -18,4 +18,7 @@ type ScimUser struct {
 	Name         *Name          `json:"name,omitempty"`
 	Active       bool           `json:"active,omitempty"`
 	UserName     string         `json:"userName,omitempty"`
+	Schemas      []string       `json:"schemas,omitempty"`
+	ExternalId   string         `json:"externalId,omitempty"`
+	Roles        []Roles        `json:"roles,omitempty"`
 }
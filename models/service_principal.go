@@ -10,6 +10,7 @@
 package models
 
 type ServicePrincipal struct {
+	Schemas       []string       `json:"schemas,omitempty"`
 	DisplayName   string         `json:"displayName,omitempty"`
 	Groups        []Groups       `json:"groups,omitempty"`
 	Id            string         `json:"id,omitempty"`
@@ -10,6 +10,7 @@
 package models
 
 type ServicePrincipalCreateRequest struct {
+	Schemas       []string       `json:"schemas,omitempty"`
 	ApplicationId string         `json:"applicationId,omitempty"`
 	DisplayName   string         `json:"displayName,omitempty"`
 	Groups        []Groups       `json:"groups,omitempty"`
@@ -0,0 +1,17 @@
+/*
+ * Databricks
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 0.0.1
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+
+package models
+
+type TokenAccessControl struct {
+	UserName             string            `json:"user_name,omitempty"`
+	GroupName            string            `json:"group_name,omitempty"`
+	ServicePrincipalName string            `json:"service_principal_name,omitempty"`
+	AllPermissions       []TokenPermission `json:"all_permissions,omitempty"`
+}
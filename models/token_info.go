@@ -10,8 +10,11 @@
 package models
 
 type TokenInfo struct {
-	TokenId      string `json:"token_id,omitempty"`
-	CreationTime int64  `json:"creation_time,omitempty"`
-	ExpiryTime   int64  `json:"expiry_time,omitempty"`
-	Comment      string `json:"comment,omitempty"`
+	TokenId           string `json:"token_id,omitempty"`
+	CreationTime      int64  `json:"creation_time,omitempty"`
+	ExpiryTime        int64  `json:"expiry_time,omitempty"`
+	Comment           string `json:"comment,omitempty"`
+	CreatedById       int64  `json:"created_by_id,omitempty"`
+	CreatedByUsername string `json:"created_by_username,omitempty"`
+	OwnerId           int64  `json:"owner_id,omitempty"`
 }
@@ -0,0 +1,15 @@
+/*
+ * Databricks
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 0.0.1
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+
+package models
+
+type TokenManagementListRequest struct {
+	CreatedById       int64  `json:"created_by_id,omitempty"`
+	CreatedByUsername string `json:"created_by_username,omitempty"`
+}
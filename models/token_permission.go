@@ -0,0 +1,16 @@
+/*
+ * Databricks
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 0.0.1
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+
+package models
+
+type TokenPermission struct {
+	PermissionLevel     *TokenPermissionLevel `json:"permission_level,omitempty"`
+	Inherited           bool                  `json:"inherited,omitempty"`
+	InheritedFromObject []string              `json:"inherited_from_object,omitempty"`
+}
@@ -0,0 +1,18 @@
+/*
+ * Databricks
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 0.0.1
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+
+package models
+
+type TokenPermissionLevel string
+
+// List of TokenPermissionLevel
+const (
+	CAN_USE    TokenPermissionLevel = "CAN_USE"
+	CAN_MANAGE TokenPermissionLevel = "CAN_MANAGE"
+)
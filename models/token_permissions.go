@@ -0,0 +1,16 @@
+/*
+ * Databricks
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 0.0.1
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+
+package models
+
+type TokenPermissions struct {
+	ObjectId          string               `json:"object_id,omitempty"`
+	ObjectType        string               `json:"object_type,omitempty"`
+	AccessControlList []TokenAccessControl `json:"access_control_list,omitempty"`
+}
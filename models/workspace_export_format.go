@@ -17,4 +17,6 @@ const (
 	HTML    WorkspaceExportFormat = "HTML"
 	JUPYTER WorkspaceExportFormat = "JUPYTER"
 	DBC     WorkspaceExportFormat = "DBC"
+	AUTO    WorkspaceExportFormat = "AUTO"
+	RAW     WorkspaceExportFormat = "RAW"
 )
@@ -15,4 +15,12 @@ type WorkspaceObjectInfo struct {
 	Path string `json:"path,omitempty"`
 
 	Language *WorkspaceLanguage `json:"language,omitempty"`
+
+	ObjectId int64 `json:"object_id,omitempty"`
+
+	CreatedAt int64 `json:"created_at,omitempty"`
+
+	ModifiedAt int64 `json:"modified_at,omitempty"`
+
+	Size int64 `json:"size,omitempty"`
 }
@@ -16,4 +16,6 @@ const (
 	NOTEBOOK  WorkspaceObjectType = "NOTEBOOK"
 	DIRECTORY WorkspaceObjectType = "DIRECTORY"
 	LIBRARY   WorkspaceObjectType = "LIBRARY"
+	FILE      WorkspaceObjectType = "FILE"
+	REPO      WorkspaceObjectType = "REPO"
 )
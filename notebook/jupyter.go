@@ -0,0 +1,153 @@
+package notebook
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/tcz001/databricks-sdk-go/models"
+)
+
+const databricksMetadataKey = "application/vnd.databricks.v1+notebook"
+
+type jupyterNotebook struct {
+	Cells         []jupyterCell          `json:"cells"`
+	Metadata      map[string]interface{} `json:"metadata"`
+	Nbformat      int                    `json:"nbformat"`
+	NbformatMinor int                    `json:"nbformat_minor"`
+}
+
+type jupyterCell struct {
+	CellType       string                 `json:"cell_type"`
+	Metadata       map[string]interface{} `json:"metadata"`
+	Source         jupyterSource          `json:"source"`
+	Outputs        *[]interface{}         `json:"outputs,omitempty"`
+	ExecutionCount *json.RawMessage       `json:"execution_count,omitempty"`
+}
+
+// jupyterSource is either a single string or a list of lines in nbformat.
+type jupyterSource string
+
+func (s *jupyterSource) UnmarshalJSON(data []byte) error {
+	var lines []string
+	if err := json.Unmarshal(data, &lines); err == nil {
+		*s = jupyterSource(strings.Join(lines, ""))
+		return nil
+	}
+
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	*s = jupyterSource(text)
+	return nil
+}
+
+func (s jupyterSource) MarshalJSON() ([]byte, error) {
+	lines := []string{}
+	if s != "" {
+		lines = strings.SplitAfter(string(s), "\n")
+	}
+	return json.Marshal(lines)
+}
+
+var jupyterLanguages = map[models.WorkspaceLanguage]string{
+	models.PYTHON: "python",
+	models.SCALA:  "scala",
+	models.SQL:    "sql",
+	models.R:      "r",
+}
+
+// Jupyter renders the notebook as nbformat v4 JSON. Cells using the %md magic
+// become markdown cells, all others become code cells keeping their magic.
+func (n *Notebook) Jupyter() ([]byte, error) {
+	language := jupyterLanguages[n.Language]
+
+	nb := jupyterNotebook{
+		Cells: make([]jupyterCell, 0, len(n.Cells)),
+		Metadata: map[string]interface{}{
+			"language_info":       map[string]interface{}{"name": language},
+			databricksMetadataKey: map[string]interface{}{"language": language},
+		},
+		Nbformat:      4,
+		NbformatMinor: 0,
+	}
+
+	for _, cell := range n.Cells {
+		if cell.Magic() == "md" {
+			nb.Cells = append(nb.Cells, jupyterCell{
+				CellType: "markdown",
+				Metadata: map[string]interface{}{},
+				Source:   jupyterSource(cell.Body()),
+			})
+			continue
+		}
+
+		// Code cells need empty outputs and a null execution count.
+		outputs := []interface{}{}
+		executionCount := json.RawMessage("null")
+		nb.Cells = append(nb.Cells, jupyterCell{
+			CellType:       "code",
+			Metadata:       map[string]interface{}{},
+			Source:         jupyterSource(cell.Source),
+			Outputs:        &outputs,
+			ExecutionCount: &executionCount,
+		})
+	}
+
+	return json.MarshalIndent(nb, "", " ")
+}
+
+// ParseJupyter reads a nbformat v4 notebook. Markdown cells are turned into
+// %md cells and raw cells are dropped.
+func ParseJupyter(content []byte) (*Notebook, error) {
+	nb := jupyterNotebook{}
+	err := json.Unmarshal(content, &nb)
+	if err != nil {
+		return nil, err
+	}
+	if nb.Nbformat != 4 {
+		return nil, fmt.Errorf("unsupported nbformat %d", nb.Nbformat)
+	}
+
+	result := Notebook{Language: jupyterLanguage(nb.Metadata)}
+	for _, cell := range nb.Cells {
+		source := strings.TrimRight(string(cell.Source), "\n")
+
+		switch cell.CellType {
+		case "markdown":
+			result.Cells = append(result.Cells, Cell{Source: "%md\n" + source})
+		case "code":
+			result.Cells = append(result.Cells, Cell{Source: source})
+		}
+	}
+
+	return &result, nil
+}
+
+func jupyterLanguage(metadata map[string]interface{}) models.WorkspaceLanguage {
+	var names []interface{}
+	if m, ok := metadata[databricksMetadataKey].(map[string]interface{}); ok {
+		names = append(names, m["language"])
+	}
+	if m, ok := metadata["language_info"].(map[string]interface{}); ok {
+		names = append(names, m["name"])
+	}
+	if m, ok := metadata["kernelspec"].(map[string]interface{}); ok {
+		names = append(names, m["language"])
+	}
+
+	for _, name := range names {
+		s, ok := name.(string)
+		if !ok {
+			continue
+		}
+		for l, n := range jupyterLanguages {
+			if strings.EqualFold(s, n) {
+				return l
+			}
+		}
+	}
+
+	return models.PYTHON
+}
@@ -0,0 +1,80 @@
+package notebook
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/tcz001/databricks-sdk-go/models"
+)
+
+const pythonSource = `# Databricks notebook source
+import os
+
+# COMMAND ----------
+
+# MAGIC %md
+# MAGIC # Title
+# MAGIC
+# MAGIC Some text
+
+# COMMAND ----------
+
+# MAGIC %sql
+# MAGIC SELECT 1
+`
+
+type NotebookTestSuite struct {
+	suite.Suite
+}
+
+func (s *NotebookTestSuite) TestParseSourceSplitsCellsAndStripsMagic() {
+	nb, err := ParseSource([]byte(pythonSource), "")
+	s.Require().NoError(err)
+
+	s.Assert().Equal(models.PYTHON, nb.Language)
+	s.Require().Len(nb.Cells, 3)
+	s.Assert().Equal("import os", nb.Cells[0].Source)
+	s.Assert().Equal("%md\n# Title\n\nSome text", nb.Cells[1].Source)
+	s.Assert().Equal("md", nb.Cells[1].Magic())
+	s.Assert().Equal("SELECT 1", nb.Cells[2].Body())
+	s.Assert().Equal("sql", nb.Cells[2].Magic())
+}
+
+func (s *NotebookTestSuite) TestSourceRoundTrips() {
+	nb, err := ParseSource([]byte(pythonSource), "")
+	s.Require().NoError(err)
+
+	s.Assert().Equal(pythonSource, string(nb.Source()))
+}
+
+func (s *NotebookTestSuite) TestParseSourceDetectsScalaHeader() {
+	nb, err := ParseSource([]byte("// Databricks notebook source\nval x = 1\n\n// COMMAND ----------\n\n// MAGIC %python\n// MAGIC print(x)\n"), models.PYTHON)
+	s.Require().NoError(err)
+
+	s.Assert().Equal(models.SCALA, nb.Language)
+	s.Assert().Equal([]Cell{{Source: "val x = 1"}, {Source: "%python\nprint(x)"}}, nb.Cells)
+}
+
+func (s *NotebookTestSuite) TestJupyterRoundTripsMagicCommands() {
+	nb, err := ParseSource([]byte(pythonSource), "")
+	s.Require().NoError(err)
+
+	content, err := nb.Jupyter()
+	s.Require().NoError(err)
+
+	var raw map[string]interface{}
+	s.Require().NoError(json.Unmarshal(content, &raw))
+	cells := raw["cells"].([]interface{})
+	s.Assert().Equal("markdown", cells[1].(map[string]interface{})["cell_type"])
+	s.Assert().Equal([]interface{}{"%sql\n", "SELECT 1"}, cells[2].(map[string]interface{})["source"])
+
+	parsed, err := ParseJupyter(content)
+	s.Require().NoError(err)
+
+	s.Assert().Equal(nb, parsed)
+}
+
+func TestNotebookSuite(t *testing.T) {
+	suite.Run(t, new(NotebookTestSuite))
+}
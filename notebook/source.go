@@ -0,0 +1,173 @@
+package notebook
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tcz001/databricks-sdk-go/models"
+)
+
+const (
+	headerText    = "Databricks notebook source"
+	separatorText = "COMMAND ----------"
+	magicText     = "MAGIC"
+)
+
+// Cell holds the source of a single notebook command. Magic commands keep
+// their first line, e.g. "%sql\nSELECT 1".
+type Cell struct {
+	Source string
+}
+
+// Magic returns the magic command of the cell without its percent sign, e.g.
+// "sql" or "md", or an empty string for cells in the notebook language.
+func (c Cell) Magic() string {
+	if !strings.HasPrefix(c.Source, "%") {
+		return ""
+	}
+
+	line := strings.SplitN(c.Source, "\n", 2)[0]
+	fields := strings.Fields(line[1:])
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+// Body returns the cell source without the magic command line.
+func (c Cell) Body() string {
+	if c.Magic() == "" {
+		return c.Source
+	}
+
+	parts := strings.SplitN(c.Source, "\n", 2)
+	if len(parts) == 1 {
+		return ""
+	}
+	return parts[1]
+}
+
+type Notebook struct {
+	Language models.WorkspaceLanguage
+	Cells    []Cell
+}
+
+// CommentPrefix returns the line comment prefix of a notebook language.
+func CommentPrefix(language models.WorkspaceLanguage) string {
+	switch language {
+	case models.SCALA:
+		return "//"
+	case models.SQL:
+		return "--"
+	default:
+		return "#"
+	}
+}
+
+// DetectLanguage infers the notebook language from the source header. Python
+// and R share the same header, in which case fallback is returned if it is
+// one of them.
+func DetectLanguage(content []byte, fallback models.WorkspaceLanguage) (models.WorkspaceLanguage, bool) {
+	firstLine := strings.TrimSpace(strings.SplitN(string(content), "\n", 2)[0])
+
+	for _, l := range []models.WorkspaceLanguage{models.SCALA, models.SQL, models.PYTHON} {
+		if firstLine == CommentPrefix(l)+" "+headerText {
+			if l == models.PYTHON && fallback == models.R {
+				return models.R, true
+			}
+			return l, true
+		}
+	}
+
+	return fallback, false
+}
+
+// ParseSource splits a notebook in Databricks source format into cells. The
+// language is taken from the header if present, otherwise language is used.
+func ParseSource(content []byte, language models.WorkspaceLanguage) (*Notebook, error) {
+	text := strings.Replace(string(content), "\r\n", "\n", -1)
+	language, hasHeader := DetectLanguage([]byte(text), language)
+	if language == "" {
+		return nil, fmt.Errorf("missing notebook language")
+	}
+
+	prefix := CommentPrefix(language)
+	lines := strings.Split(text, "\n")
+	if hasHeader {
+		lines = lines[1:]
+	}
+
+	nb := Notebook{Language: language}
+	var current []string
+	for _, line := range lines {
+		if strings.TrimRight(line, " ") == prefix+" "+separatorText {
+			nb.Cells = append(nb.Cells, newCell(current, prefix))
+			current = nil
+			continue
+		}
+		current = append(current, line)
+	}
+	nb.Cells = append(nb.Cells, newCell(current, prefix))
+
+	return &nb, nil
+}
+
+func newCell(lines []string, prefix string) Cell {
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	magic := prefix + " " + magicText
+	isMagic := len(lines) > 0
+	for _, line := range lines {
+		if line != magic && !strings.HasPrefix(line, magic+" ") {
+			isMagic = false
+			break
+		}
+	}
+
+	if isMagic {
+		unmagic := make([]string, len(lines))
+		for i, line := range lines {
+			unmagic[i] = strings.TrimPrefix(strings.TrimPrefix(line, magic), " ")
+		}
+		lines = unmagic
+	}
+
+	return Cell{Source: strings.Join(lines, "\n")}
+}
+
+// Source renders the notebook in Databricks source format.
+func (n *Notebook) Source() []byte {
+	prefix := CommentPrefix(n.Language)
+
+	var b strings.Builder
+	b.WriteString(prefix + " " + headerText + "\n")
+
+	for i, cell := range n.Cells {
+		if i > 0 {
+			b.WriteString("\n\n" + prefix + " " + separatorText + "\n\n")
+		}
+
+		if cell.Magic() == "" {
+			b.WriteString(cell.Source)
+			continue
+		}
+
+		for j, line := range strings.Split(cell.Source, "\n") {
+			if j > 0 {
+				b.WriteString("\n")
+			}
+			b.WriteString(prefix + " " + magicText)
+			if line != "" {
+				b.WriteString(" " + line)
+			}
+		}
+	}
+
+	b.WriteString("\n")
+	return []byte(b.String())
+}
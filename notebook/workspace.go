@@ -0,0 +1,52 @@
+package notebook
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/tcz001/databricks-sdk-go/models"
+)
+
+// Decode parses the content of a workspace export in SOURCE or JUPYTER
+// format. The language is used for source exports without header.
+func Decode(resp *models.WorkspaceExportResponse, format models.WorkspaceExportFormat, language models.WorkspaceLanguage) (*Notebook, error) {
+	content, err := base64.StdEncoding.DecodeString(resp.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case models.SOURCE:
+		return ParseSource(content, language)
+	case models.JUPYTER:
+		return ParseJupyter(content)
+	}
+
+	return nil, fmt.Errorf("unsupported notebook format %s", format)
+}
+
+// ImportRequest builds a request importing the notebook at path in SOURCE or
+// JUPYTER format.
+func (n *Notebook) ImportRequest(path string, format models.WorkspaceExportFormat) (*models.WorkspaceImportRequest, error) {
+	var content []byte
+	switch format {
+	case models.SOURCE:
+		content = n.Source()
+	case models.JUPYTER:
+		var err error
+		content, err = n.Jupyter()
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported notebook format %s", format)
+	}
+
+	language := n.Language
+	return &models.WorkspaceImportRequest{
+		Path:     path,
+		Format:   &format,
+		Language: &language,
+		Content:  base64.StdEncoding.EncodeToString(content),
+	}, nil
+}
@@ -0,0 +1,185 @@
+package tokenaudit
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/tcz001/databricks-sdk-go/api/token"
+	"github.com/tcz001/databricks-sdk-go/api/tokenmanagement"
+	"github.com/tcz001/databricks-sdk-go/models"
+)
+
+type Status string
+
+const (
+	Valid        Status = "VALID"
+	NoExpiry     Status = "NO_EXPIRY"
+	ExpiringSoon Status = "EXPIRING_SOON"
+	Expired      Status = "EXPIRED"
+)
+
+type Options struct {
+	// Window flags tokens expiring within this duration as ExpiringSoon.
+	Window time.Duration
+
+	// Now is the reference time of the report. It defaults to time.Now().
+	Now time.Time
+}
+
+type Entry struct {
+	TokenId           string     `json:"token_id"`
+	Comment           string     `json:"comment,omitempty"`
+	CreatedById       int64      `json:"created_by_id,omitempty"`
+	CreatedByUsername string     `json:"created_by_username,omitempty"`
+	OwnerId           int64      `json:"owner_id,omitempty"`
+	CreationTime      time.Time  `json:"creation_time"`
+	ExpiryTime        *time.Time `json:"expiry_time,omitempty"`
+	Status            Status     `json:"status"`
+}
+
+// Flagged reports whether the entry needs attention.
+func (e Entry) Flagged() bool {
+	return e.Status != Valid
+}
+
+type Report struct {
+	GeneratedAt time.Time     `json:"generated_at"`
+	Window      time.Duration `json:"window"`
+	Entries     []Entry       `json:"entries"`
+}
+
+// Own builds a report of the tokens owned by the caller.
+func Own(endpoint *token.Endpoint, opts Options) (*Report, error) {
+	resp, err := endpoint.List()
+	if err != nil {
+		return nil, err
+	}
+
+	return Build(resp.TokenInfos, opts), nil
+}
+
+// All builds a report of all tokens in the workspace matching request. It
+// requires workspace admin rights.
+func All(endpoint *tokenmanagement.Endpoint, request *models.TokenManagementListRequest, opts Options) (*Report, error) {
+	resp, err := endpoint.List(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return Build(resp.TokenInfos, opts), nil
+}
+
+func Build(tokens []models.TokenInfo, opts Options) *Report {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	report := Report{
+		GeneratedAt: now,
+		Window:      opts.Window,
+		Entries:     make([]Entry, 0, len(tokens)),
+	}
+
+	for _, t := range tokens {
+		entry := Entry{
+			TokenId:           t.TokenId,
+			Comment:           t.Comment,
+			CreatedById:       t.CreatedById,
+			CreatedByUsername: t.CreatedByUsername,
+			OwnerId:           t.OwnerId,
+			CreationTime:      fromMillis(t.CreationTime),
+		}
+
+		// Tokens without lifetime are reported with an expiry time of -1.
+		if t.ExpiryTime <= 0 {
+			entry.Status = NoExpiry
+		} else {
+			expiry := fromMillis(t.ExpiryTime)
+			entry.ExpiryTime = &expiry
+
+			switch {
+			case !expiry.After(now):
+				entry.Status = Expired
+			case expiry.Before(now.Add(opts.Window)):
+				entry.Status = ExpiringSoon
+			default:
+				entry.Status = Valid
+			}
+		}
+
+		report.Entries = append(report.Entries, entry)
+	}
+
+	return &report
+}
+
+// Flagged returns the entries that need attention.
+func (r *Report) Flagged() []Entry {
+	var flagged []Entry
+	for _, e := range r.Entries {
+		if e.Flagged() {
+			flagged = append(flagged, e)
+		}
+	}
+
+	return flagged
+}
+
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+var csvHeader = []string{
+	"token_id", "comment", "created_by_id", "created_by_username", "owner_id", "creation_time", "expiry_time", "status",
+}
+
+func (r *Report) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	err := writer.Write(csvHeader)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range r.Entries {
+		expiry := ""
+		if e.ExpiryTime != nil {
+			expiry = e.ExpiryTime.Format(time.RFC3339)
+		}
+
+		err = writer.Write([]string{
+			e.TokenId,
+			e.Comment,
+			formatId(e.CreatedById),
+			e.CreatedByUsername,
+			formatId(e.OwnerId),
+			e.CreationTime.Format(time.RFC3339),
+			expiry,
+			string(e.Status),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func fromMillis(ms int64) time.Time {
+	return time.Unix(0, ms*int64(time.Millisecond)).UTC()
+}
+
+func formatId(id int64) string {
+	if id == 0 {
+		return ""
+	}
+
+	return strconv.FormatInt(id, 10)
+}
@@ -0,0 +1,89 @@
+package tokenaudit
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/tcz001/databricks-sdk-go/models"
+)
+
+type ReportTestSuite struct {
+	suite.Suite
+	now time.Time
+}
+
+func (s *ReportTestSuite) SetupTest() {
+	s.now = time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func (s *ReportTestSuite) millis(d time.Duration) int64 {
+	return s.now.Add(d).UnixNano() / int64(time.Millisecond)
+}
+
+func (s *ReportTestSuite) TestBuildClassifiesTokens() {
+	window := 7 * 24 * time.Hour
+	tests := []struct {
+		name       string
+		expiryTime int64
+		status     Status
+	}{
+		{"no lifetime", -1, NoExpiry},
+		{"zero expiry", 0, NoExpiry},
+		{"expired", s.millis(-time.Hour), Expired},
+		{"expiring now", s.millis(0), Expired},
+		{"expiring within window", s.millis(window - time.Millisecond), ExpiringSoon},
+		{"expiring at window end", s.millis(window), Valid},
+		{"expiring after window", s.millis(window + time.Hour), Valid},
+	}
+
+	for _, test := range tests {
+		report := Build([]models.TokenInfo{{TokenId: "id", ExpiryTime: test.expiryTime}}, Options{Window: window, Now: s.now})
+
+		s.Require().Len(report.Entries, 1, test.name)
+		s.Assert().Equal(test.status, report.Entries[0].Status, test.name)
+		s.Assert().Equal(test.status != Valid, report.Entries[0].Flagged(), test.name)
+		s.Assert().Equal(test.expiryTime > 0, report.Entries[0].ExpiryTime != nil, test.name)
+	}
+}
+
+func (s *ReportTestSuite) TestFlagged() {
+	report := Build([]models.TokenInfo{
+		{TokenId: "valid", ExpiryTime: s.millis(30 * 24 * time.Hour)},
+		{TokenId: "forever", ExpiryTime: -1},
+		{TokenId: "expired", ExpiryTime: s.millis(-time.Hour)},
+	}, Options{Window: time.Hour, Now: s.now})
+
+	flagged := report.Flagged()
+	s.Require().Len(flagged, 2)
+	s.Assert().Equal("forever", flagged[0].TokenId)
+	s.Assert().Equal("expired", flagged[1].TokenId)
+}
+
+func (s *ReportTestSuite) TestWriteCSV() {
+	report := Build([]models.TokenInfo{
+		{
+			TokenId:           "a",
+			Comment:           "ci, nightly",
+			CreatedById:       42,
+			CreatedByUsername: "user@example.com",
+			CreationTime:      s.millis(-24 * time.Hour),
+			ExpiryTime:        s.millis(time.Hour),
+		},
+		{TokenId: "b", CreationTime: s.millis(-time.Hour), ExpiryTime: -1},
+	}, Options{Window: 2 * time.Hour, Now: s.now})
+
+	buf := bytes.Buffer{}
+	s.Require().NoError(report.WriteCSV(&buf))
+
+	s.Assert().Equal(
+		"token_id,comment,created_by_id,created_by_username,owner_id,creation_time,expiry_time,status\n"+
+			"a,\"ci, nightly\",42,user@example.com,,2021-05-31T12:00:00Z,2021-06-01T13:00:00Z,EXPIRING_SOON\n"+
+			"b,,,,,2021-06-01T11:00:00Z,,NO_EXPIRY\n",
+		buf.String())
+}
+
+func TestReportTestSuite(t *testing.T) {
+	suite.Run(t, new(ReportTestSuite))
+}
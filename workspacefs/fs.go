@@ -0,0 +1,340 @@
+package workspacefs
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/tcz001/databricks-sdk-go/api/workspace"
+	"github.com/tcz001/databricks-sdk-go/client"
+	"github.com/tcz001/databricks-sdk-go/models"
+)
+
+// api is the read-only subset of workspace.Endpoint used by FS, which
+// guarantees that the file system never modifies the workspace.
+type api interface {
+	List(request *models.WorkspaceListRequest) (*models.WorkspaceListResponse, error)
+	GetStatus(request *models.WorkspaceGetStatusRequest) (*models.WorkspaceGetStatusResponse, error)
+	Export(request *models.WorkspaceExportRequest) (*models.WorkspaceExportResponse, error)
+}
+
+type Options struct {
+	// Root is the workspace directory the file system is rooted at. It
+	// defaults to "/".
+	Root string
+
+	// CacheTTL keeps directory listings in memory for the given duration.
+	// Listings are not cached when it is zero.
+	CacheTTL time.Duration
+}
+
+// FS exposes workspace content as a read-only fs.FS. Notebooks are read in
+// SOURCE format, and the size of every file is the length of that content,
+// so stating a file exports it.
+type FS struct {
+	api  api
+	root string
+	ttl  time.Duration
+
+	mu    sync.Mutex
+	cache map[string]listing
+}
+
+type listing struct {
+	objects []models.WorkspaceObjectInfo
+	expires time.Time
+}
+
+var (
+	_ fs.FS         = (*FS)(nil)
+	_ fs.ReadDirFS  = (*FS)(nil)
+	_ fs.StatFS     = (*FS)(nil)
+	_ fs.ReadFileFS = (*FS)(nil)
+)
+
+func New(endpoint *workspace.Endpoint, opts Options) *FS {
+	if opts.Root == "" {
+		opts.Root = "/"
+	}
+
+	return &FS{
+		api:   endpoint,
+		root:  opts.Root,
+		ttl:   opts.CacheTTL,
+		cache: map[string]listing{},
+	}
+}
+
+func (f *FS) remotePath(name string) string {
+	return path.Join(f.root, name)
+}
+
+func (f *FS) Stat(name string) (fs.FileInfo, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrInvalid}
+	}
+
+	obj, err := f.object(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: err}
+	}
+
+	info := workspace.NewFileInfo(obj)
+	if info.IsDir() {
+		return info, nil
+	}
+
+	content, err := f.export(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: err}
+	}
+
+	return sized(obj, content), nil
+}
+
+// object returns the metadata of name, from the cached listing of its parent
+// directory if available.
+func (f *FS) object(name string) (models.WorkspaceObjectInfo, error) {
+	if name != "." {
+		if objects, ok := f.cached(path.Dir(name)); ok {
+			for _, obj := range objects {
+				if path.Base(obj.Path) == path.Base(name) {
+					return obj, nil
+				}
+			}
+			return models.WorkspaceObjectInfo{}, fs.ErrNotExist
+		}
+	}
+
+	resp, err := f.api.GetStatus(&models.WorkspaceGetStatusRequest{Path: f.remotePath(name)})
+	if err != nil {
+		return models.WorkspaceObjectInfo{}, mapError(err)
+	}
+
+	return models.WorkspaceObjectInfo{
+		ObjectType: resp.ObjectType,
+		Path:       resp.Path,
+		Language:   resp.Language,
+		ObjectId:   resp.ObjectId,
+		CreatedAt:  resp.CreatedAt,
+		ModifiedAt: resp.ModifiedAt,
+		Size:       resp.Size,
+	}, nil
+}
+
+func (f *FS) export(name string) ([]byte, error) {
+	format := models.SOURCE
+	resp, err := f.api.Export(&models.WorkspaceExportRequest{Path: f.remotePath(name), Format: &format})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return base64.StdEncoding.DecodeString(resp.Content)
+}
+
+// sized returns the info of a file whose size is the length of its exported
+// content rather than the size reported by the workspace.
+func sized(obj models.WorkspaceObjectInfo, content []byte) fs.FileInfo {
+	obj.Size = int64(len(content))
+	return workspace.NewFileInfo(obj)
+}
+
+func (f *FS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+
+	objects, ok := f.cached(name)
+	if !ok {
+		resp, err := f.api.List(&models.WorkspaceListRequest{Path: f.remotePath(name)})
+		if err != nil {
+			return nil, &fs.PathError{Op: "readdir", Path: name, Err: mapError(err)}
+		}
+
+		objects = resp.Objects
+		sort.Slice(objects, func(i, j int) bool { return path.Base(objects[i].Path) < path.Base(objects[j].Path) })
+
+		if f.ttl > 0 {
+			f.mu.Lock()
+			f.cache[name] = listing{objects: objects, expires: time.Now().Add(f.ttl)}
+			f.mu.Unlock()
+		}
+	}
+
+	entries := make([]fs.DirEntry, 0, len(objects))
+	for _, obj := range objects {
+		info := workspace.NewFileInfo(obj)
+		if info.IsDir() {
+			entries = append(entries, fs.FileInfoToDirEntry(info))
+		} else {
+			entries = append(entries, &fileEntry{fs: f, name: path.Join(name, info.Name()), info: info})
+		}
+	}
+
+	return entries, nil
+}
+
+func (f *FS) ReadFile(name string) ([]byte, error) {
+	file, err := f.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	if _, ok := file.(*dir); ok {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: errIsDir}
+	}
+
+	return io.ReadAll(file)
+}
+
+func (f *FS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	obj, err := f.object(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	info := workspace.NewFileInfo(obj)
+	if info.IsDir() {
+		return &dir{fs: f, name: name, info: info}, nil
+	}
+
+	content, err := f.export(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	return &file{Reader: bytes.NewReader(content), info: sized(obj, content)}, nil
+}
+
+// Invalidate drops all cached directory listings.
+func (f *FS) Invalidate() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cache = map[string]listing{}
+}
+
+func (f *FS) cached(name string) ([]models.WorkspaceObjectInfo, bool) {
+	if f.ttl <= 0 {
+		return nil, false
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	l, ok := f.cache[name]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(l.expires) {
+		delete(f.cache, name)
+		return nil, false
+	}
+
+	return l.objects, true
+}
+
+func mapError(err error) error {
+	if derr, ok := err.(client.Error); ok && derr.Code() == "RESOURCE_DOES_NOT_EXIST" {
+		return fs.ErrNotExist
+	}
+
+	return err
+}
+
+var errIsDir = errors.New("is a directory")
+
+type file struct {
+	*bytes.Reader
+	info fs.FileInfo
+}
+
+func (f *file) Stat() (fs.FileInfo, error) {
+	return f.info, nil
+}
+
+func (f *file) Close() error {
+	return nil
+}
+
+// fileEntry is the directory entry of a file. Its info is only fetched when
+// requested, since sizing the file requires exporting it.
+type fileEntry struct {
+	fs   *FS
+	name string
+	info fs.FileInfo
+}
+
+func (e *fileEntry) Name() string {
+	return e.info.Name()
+}
+
+func (e *fileEntry) IsDir() bool {
+	return false
+}
+
+func (e *fileEntry) Type() fs.FileMode {
+	return e.info.Mode().Type()
+}
+
+func (e *fileEntry) Info() (fs.FileInfo, error) {
+	return e.fs.Stat(e.name)
+}
+
+type dir struct {
+	fs      *FS
+	name    string
+	info    fs.FileInfo
+	entries []fs.DirEntry
+	loaded  bool
+}
+
+func (d *dir) Stat() (fs.FileInfo, error) {
+	return d.info, nil
+}
+
+func (d *dir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.name, Err: errIsDir}
+}
+
+func (d *dir) Close() error {
+	return nil
+}
+
+func (d *dir) ReadDir(n int) ([]fs.DirEntry, error) {
+	if !d.loaded {
+		entries, err := d.fs.ReadDir(d.name)
+		if err != nil {
+			return nil, err
+		}
+		d.entries = entries
+		d.loaded = true
+	}
+
+	if n <= 0 {
+		entries := d.entries
+		d.entries = nil
+		return entries, nil
+	}
+
+	if len(d.entries) == 0 {
+		return nil, io.EOF
+	}
+	if n > len(d.entries) {
+		n = len(d.entries)
+	}
+
+	entries := d.entries[:n]
+	d.entries = d.entries[n:]
+	return entries, nil
+}
@@ -0,0 +1,159 @@
+package workspacefs
+
+import (
+	"encoding/base64"
+	"io/fs"
+	"path"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/tcz001/databricks-sdk-go/client"
+	"github.com/tcz001/databricks-sdk-go/models"
+)
+
+// fakeApi serves a fixed workspace tree of notebooks and directories. Like the
+// workspace, it reports a notebook size unrelated to its exported content.
+type fakeApi struct {
+	notebooks map[string]string
+	lists     int
+}
+
+func (a *fakeApi) object(p string) (models.WorkspaceObjectInfo, bool) {
+	objectType := models.NOTEBOOK
+	if _, ok := a.notebooks[p]; ok {
+		return models.WorkspaceObjectInfo{Path: p, ObjectType: &objectType, Size: 4096}, true
+	}
+
+	objectType = models.DIRECTORY
+	for nb := range a.notebooks {
+		if strings.HasPrefix(nb, p+"/") || p == "/" {
+			return models.WorkspaceObjectInfo{Path: p, ObjectType: &objectType}, true
+		}
+	}
+
+	return models.WorkspaceObjectInfo{}, false
+}
+
+func (a *fakeApi) List(request *models.WorkspaceListRequest) (*models.WorkspaceListResponse, error) {
+	a.lists++
+	seen := map[string]bool{}
+	resp := models.WorkspaceListResponse{}
+	for nb := range a.notebooks {
+		if !strings.HasPrefix(nb, strings.TrimSuffix(request.Path, "/")+"/") {
+			continue
+		}
+		rest := strings.TrimPrefix(nb, strings.TrimSuffix(request.Path, "/")+"/")
+		child := path.Join(request.Path, strings.SplitN(rest, "/", 2)[0])
+		if !seen[child] {
+			seen[child] = true
+			obj, _ := a.object(child)
+			resp.Objects = append(resp.Objects, obj)
+		}
+	}
+
+	return &resp, nil
+}
+
+func (a *fakeApi) GetStatus(request *models.WorkspaceGetStatusRequest) (*models.WorkspaceGetStatusResponse, error) {
+	obj, ok := a.object(request.Path)
+	if !ok {
+		return nil, client.NewError(models.ErrorResponse{ErrorCode: "RESOURCE_DOES_NOT_EXIST"}, 404)
+	}
+
+	return &models.WorkspaceGetStatusResponse{Path: obj.Path, ObjectType: obj.ObjectType, Size: obj.Size}, nil
+}
+
+func (a *fakeApi) Export(request *models.WorkspaceExportRequest) (*models.WorkspaceExportResponse, error) {
+	return &models.WorkspaceExportResponse{
+		Content: base64.StdEncoding.EncodeToString([]byte(a.notebooks[request.Path])),
+	}, nil
+}
+
+type FSTestSuite struct {
+	suite.Suite
+	api *fakeApi
+}
+
+func (s *FSTestSuite) SetupTest() {
+	s.api = &fakeApi{notebooks: map[string]string{
+		"/Shared/etl/load":      "# Databricks notebook source\nprint('load')",
+		"/Shared/etl/transform": "# Databricks notebook source\nprint('transform')",
+		"/Shared/report":        "-- Databricks notebook source\nSELECT 1",
+	}}
+}
+
+func (s *FSTestSuite) newFS(cacheTTL time.Duration) *FS {
+	return &FS{api: s.api, root: "/Shared", ttl: cacheTTL, cache: map[string]listing{}}
+}
+
+func (s *FSTestSuite) TestFSConformsToFstest() {
+	err := fstest.TestFS(s.newFS(0), "etl/load", "etl/transform", "report")
+	s.Require().NoError(err)
+}
+
+func (s *FSTestSuite) TestWalkDirAndReadFile() {
+	fsys := s.newFS(0)
+
+	var files []string
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			files = append(files, p)
+		}
+		return err
+	})
+	s.Require().NoError(err)
+	s.Assert().Equal([]string{"etl/load", "etl/transform", "report"}, files)
+
+	content, err := fs.ReadFile(fsys, "report")
+	s.Require().NoError(err)
+	s.Assert().Equal("-- Databricks notebook source\nSELECT 1", string(content))
+
+	_, err = fsys.Stat("missing")
+	s.Assert().ErrorIs(err, fs.ErrNotExist)
+}
+
+func (s *FSTestSuite) TestFileSizeIsContentLength() {
+	fsys := s.newFS(time.Minute)
+	size := int64(len(s.api.notebooks["/Shared/report"]))
+
+	info, err := fsys.Stat("report")
+	s.Require().NoError(err)
+	s.Assert().Equal(size, info.Size())
+
+	file, err := fsys.Open("report")
+	s.Require().NoError(err)
+	defer file.Close()
+	info, err = file.Stat()
+	s.Require().NoError(err)
+	s.Assert().Equal(size, info.Size())
+
+	entries, err := fsys.ReadDir(".")
+	s.Require().NoError(err)
+	s.Require().Len(entries, 2)
+	s.Assert().Equal("report", entries[1].Name())
+	info, err = entries[1].Info()
+	s.Require().NoError(err)
+	s.Assert().Equal(size, info.Size())
+}
+
+func (s *FSTestSuite) TestListingsAreCached() {
+	fsys := s.newFS(time.Minute)
+
+	for i := 0; i < 3; i++ {
+		_, err := fsys.ReadDir("etl")
+		s.Require().NoError(err)
+	}
+	s.Assert().Equal(1, s.api.lists)
+
+	fsys.Invalidate()
+	_, err := fsys.ReadDir("etl")
+	s.Require().NoError(err)
+	s.Assert().Equal(2, s.api.lists)
+}
+
+func TestFSSuite(t *testing.T) {
+	suite.Run(t, new(FSTestSuite))
+}
@@ -0,0 +1,89 @@
+package workspacesync
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// FileState records what was last pushed for a local file.
+type FileState struct {
+	Hash    string `json:"hash"`
+	Remote  string `json:"remote"`
+	ModTime int64  `json:"mod_time"`
+	Size    int64  `json:"size"`
+}
+
+// State is the snapshot persisted between runs. Paths are relative to the
+// synced local directory and use forward slashes.
+type State struct {
+	RemotePath string               `json:"remote_path"`
+	Files      map[string]FileState `json:"files"`
+	Dirs       map[string]bool      `json:"dirs"`
+}
+
+func newState(remotePath string) *State {
+	return &State{
+		RemotePath: remotePath,
+		Files:      map[string]FileState{},
+		Dirs:       map[string]bool{},
+	}
+}
+
+// LoadState reads a state snapshot. A missing file yields an empty state.
+func LoadState(file string, remotePath string) (*State, error) {
+	content, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return newState(remotePath), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	state := newState(remotePath)
+	err = json.Unmarshal(content, state)
+	if err != nil {
+		return nil, err
+	}
+
+	// A snapshot taken for another destination says nothing about what
+	// exists remotely, so everything needs to be pushed again.
+	if state.RemotePath != remotePath {
+		return newState(remotePath), nil
+	}
+
+	// A snapshot written by hand may hold null instead of an empty object.
+	if state.Files == nil {
+		state.Files = map[string]FileState{}
+	}
+	if state.Dirs == nil {
+		state.Dirs = map[string]bool{}
+	}
+
+	return state, nil
+}
+
+// Save writes the snapshot atomically.
+func (s *State) Save(file string) error {
+	content, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(file), ".sync-state")
+	if err != nil {
+		return err
+	}
+
+	_, err = tmp.Write(content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), file)
+}
@@ -0,0 +1,403 @@
+package workspacesync
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tcz001/databricks-sdk-go/api/workspace"
+	"github.com/tcz001/databricks-sdk-go/client"
+	"github.com/tcz001/databricks-sdk-go/models"
+)
+
+const (
+	DefaultInterval  = 1 * time.Second
+	DefaultDebounce  = 2 * time.Second
+	DefaultStateFile = ".databricks-sync.json"
+)
+
+type Options struct {
+	LocalDir   string
+	RemotePath string
+
+	// Interval is the polling interval of Run.
+	Interval time.Duration
+
+	// Debounce is how long the local directory needs to be quiet before
+	// changes are pushed.
+	Debounce time.Duration
+
+	// StateFile defaults to DefaultStateFile inside LocalDir.
+	StateFile string
+}
+
+type Result struct {
+	Imported []string
+	Deleted  []string
+	Created  []string
+}
+
+func (r *Result) Empty() bool {
+	return len(r.Imported) == 0 && len(r.Deleted) == 0 && len(r.Created) == 0
+}
+
+type Syncer struct {
+	endpoint *workspace.Endpoint
+	opts     Options
+	state    *State
+}
+
+func NewSyncer(endpoint *workspace.Endpoint, opts Options) (*Syncer, error) {
+	if opts.LocalDir == "" || opts.RemotePath == "" {
+		return nil, fmt.Errorf("missing local directory or remote path")
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultInterval
+	}
+	if opts.Debounce <= 0 {
+		opts.Debounce = DefaultDebounce
+	}
+	if opts.StateFile == "" {
+		opts.StateFile = filepath.Join(opts.LocalDir, DefaultStateFile)
+	}
+
+	state, err := LoadState(opts.StateFile, opts.RemotePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Syncer{endpoint: endpoint, opts: opts, state: state}, nil
+}
+
+// Run polls the local directory until ctx is done and pushes changes once
+// they have settled for the debounce duration. Failed scans and syncs are
+// logged and retried on the next tick.
+func (s *Syncer) Run(ctx context.Context) error {
+	// An empty fingerprint never matches a scan, so a failed initial sync is
+	// retried once the directory has been quiet for the debounce duration.
+	synced := ""
+	_, err := s.SyncOnce()
+	if err != nil {
+		log.Printf("[ERROR] sync %s: %v", s.opts.LocalDir, err)
+	} else if synced, err = s.fingerprint(); err != nil {
+		log.Printf("[ERROR] scanning %s: %v", s.opts.LocalDir, err)
+	}
+
+	ticker := time.NewTicker(s.opts.Interval)
+	defer ticker.Stop()
+
+	last, lastChange := synced, time.Now()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		current, err := s.fingerprint()
+		if err != nil {
+			log.Printf("[ERROR] scanning %s: %v", s.opts.LocalDir, err)
+			continue
+		}
+
+		if current != last {
+			last, lastChange = current, time.Now()
+			continue
+		}
+
+		if current == synced || time.Since(lastChange) < s.opts.Debounce {
+			continue
+		}
+
+		result, err := s.SyncOnce()
+		if err != nil {
+			log.Printf("[ERROR] sync %s: %v", s.opts.LocalDir, err)
+			continue
+		}
+		if !result.Empty() {
+			log.Printf("[INFO] sync %s: %d imported, %d deleted, %d directories created",
+				s.opts.LocalDir, len(result.Imported), len(result.Deleted), len(result.Created))
+		}
+
+		synced = current
+	}
+}
+
+type localFile struct {
+	abs     string
+	remote  string
+	format  models.WorkspaceExportFormat
+	lang    *models.WorkspaceLanguage
+	modTime int64
+	size    int64
+}
+
+// SyncOnce pushes all local changes since the last snapshot and saves the
+// new snapshot. A renamed file is deleted remotely and imported again.
+func (s *Syncer) SyncOnce() (*Result, error) {
+	files, dirs, err := s.scan()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+
+	for _, rel := range sortedStates(s.state.Files) {
+		if _, ok := files[rel]; ok {
+			continue
+		}
+
+		remote := s.state.Files[rel].Remote
+		err := s.delete(remote)
+		if err != nil {
+			return result, s.saveAfter(fmt.Errorf("deleting %s: %v", remote, err))
+		}
+		delete(s.state.Files, rel)
+		result.Deleted = append(result.Deleted, remote)
+	}
+
+	for _, rel := range sortedDirs(s.state.Dirs) {
+		if dirs[rel] || !s.state.Dirs[rel] {
+			continue
+		}
+
+		remote := path.Join(s.opts.RemotePath, rel)
+		err := s.delete(remote)
+		if err != nil {
+			return result, s.saveAfter(fmt.Errorf("deleting %s: %v", remote, err))
+		}
+		for d := range s.state.Dirs {
+			if d == rel || strings.HasPrefix(d, rel+"/") {
+				delete(s.state.Dirs, d)
+			}
+		}
+		result.Deleted = append(result.Deleted, remote)
+	}
+
+	for _, rel := range sortedDirs(dirs) {
+		if s.state.Dirs[rel] {
+			continue
+		}
+
+		remote := path.Join(s.opts.RemotePath, rel)
+		err := s.endpoint.Mkdirs(&models.WorkspaceMkdirsRequest{Path: remote})
+		if err != nil {
+			return result, s.saveAfter(fmt.Errorf("creating %s: %v", remote, err))
+		}
+		s.state.Dirs[rel] = true
+		result.Created = append(result.Created, remote)
+	}
+
+	for _, rel := range sortedFiles(files) {
+		f := files[rel]
+		prev, known := s.state.Files[rel]
+		if known && prev.Remote == f.remote && prev.ModTime == f.modTime && prev.Size == f.size {
+			continue
+		}
+
+		content, err := ioutil.ReadFile(f.abs)
+		if err != nil {
+			return result, s.saveAfter(err)
+		}
+
+		sum := sha256.Sum256(content)
+		hash := hex.EncodeToString(sum[:])
+		next := FileState{Hash: hash, Remote: f.remote, ModTime: f.modTime, Size: f.size}
+
+		if known && prev.Remote == f.remote && prev.Hash == hash {
+			s.state.Files[rel] = next
+			continue
+		}
+
+		if f.format == models.RAW {
+			err = s.endpoint.ImportFile(f.remote, content, true)
+		} else {
+			format := f.format
+			err = s.endpoint.Import(&models.WorkspaceImportRequest{
+				Path:      f.remote,
+				Format:    &format,
+				Language:  f.lang,
+				Content:   base64.StdEncoding.EncodeToString(content),
+				Overwrite: true,
+			})
+		}
+		if err != nil {
+			return result, s.saveAfter(fmt.Errorf("importing %s: %v", f.remote, err))
+		}
+
+		s.state.Files[rel] = next
+		result.Imported = append(result.Imported, f.remote)
+	}
+
+	return result, s.state.Save(s.opts.StateFile)
+}
+
+// saveAfter persists the progress made so far so that a restart does not
+// push it again, and returns cause.
+func (s *Syncer) saveAfter(cause error) error {
+	err := s.state.Save(s.opts.StateFile)
+	if err != nil {
+		log.Printf("[ERROR] saving sync state %s: %v", s.opts.StateFile, err)
+	}
+
+	return cause
+}
+
+func (s *Syncer) delete(remote string) error {
+	err := s.endpoint.Delete(&models.WorkspaceDeleteRequest{Path: remote, Recursive: true})
+	if derr, ok := err.(client.Error); ok && derr.Code() == "RESOURCE_DOES_NOT_EXIST" {
+		return nil
+	}
+
+	return err
+}
+
+func (s *Syncer) scan() (map[string]localFile, map[string]bool, error) {
+	files := map[string]localFile{}
+	dirs := map[string]bool{}
+	stateFile, _ := filepath.Abs(s.opts.StateFile)
+
+	err := filepath.Walk(s.opts.LocalDir, func(localPath string, info os.FileInfo, err error) error {
+		// Editors create and remove temporary files all the time; one that
+		// is gone by the time it is read is simply not part of this scan.
+		if os.IsNotExist(err) && localPath != s.opts.LocalDir {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(s.opts.LocalDir, localPath)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+
+		if strings.HasPrefix(info.Name(), ".") {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if info.IsDir() {
+			dirs[rel] = true
+			return nil
+		}
+
+		if abs, _ := filepath.Abs(localPath); abs == stateFile {
+			return nil
+		}
+
+		content, err := readHeader(localPath)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// Files that are not notebooks are pushed as workspace files under
+		// their full name.
+		format, lang, name, ok := workspace.DetectNotebook(info.Name(), content)
+		if !ok {
+			format, lang, name = models.RAW, nil, info.Name()
+		}
+
+		files[rel] = localFile{
+			abs:     localPath,
+			remote:  path.Join(s.opts.RemotePath, path.Dir(rel), name),
+			format:  format,
+			lang:    lang,
+			modTime: info.ModTime().UnixNano(),
+			size:    info.Size(),
+		}
+
+		return nil
+	})
+
+	return files, dirs, err
+}
+
+// fingerprint summarizes names, sizes and modification times of the local
+// directory so that changes can be detected without reading file contents.
+func (s *Syncer) fingerprint() (string, error) {
+	files, dirs, err := s.scan()
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	for _, rel := range sortedFiles(files) {
+		fmt.Fprintf(h, "f %s %d %d\n", rel, files[rel].modTime, files[rel].size)
+	}
+	for _, rel := range sortedDirs(dirs) {
+		fmt.Fprintf(h, "d %s\n", rel)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// readHeader reads the first bytes of a file, which is enough to detect
+// notebooks by their header line.
+func readHeader(file string) ([]byte, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	buf := make([]byte, 64)
+	n, err := f.Read(buf)
+	if n == 0 && err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	return buf[:n], nil
+}
+
+func sortedFiles(files map[string]localFile) []string {
+	keys := make([]string, 0, len(files))
+	for k := range files {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func sortedStates(states map[string]FileState) []string {
+	keys := make([]string, 0, len(states))
+	for k := range states {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// sortedDirs returns parents before their children.
+func sortedDirs(dirs map[string]bool) []string {
+	keys := make([]string, 0, len(dirs))
+	for k := range dirs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
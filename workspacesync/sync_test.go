@@ -0,0 +1,164 @@
+package workspacesync
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/tcz001/databricks-sdk-go/api/workspace"
+	"github.com/tcz001/databricks-sdk-go/client"
+	"gopkg.in/h2non/gock.v1"
+)
+
+type SyncTestSuite struct {
+	suite.Suite
+	dir      string
+	endpoint *workspace.Endpoint
+}
+
+func (s *SyncTestSuite) SetupTest() {
+	dir, err := ioutil.TempDir("", "workspacesync")
+	s.Require().NoError(err)
+	s.dir = dir
+
+	domain := "server.com"
+	token := "a_token"
+	cl, err := client.NewClient(client.Options{Domain: &domain, Token: &token, RateLimitPerSecond: 100})
+	s.Require().NoError(err)
+
+	s.endpoint = &workspace.Endpoint{Client: cl}
+}
+
+func (s *SyncTestSuite) TearDownTest() {
+	gock.Off()
+	os.RemoveAll(s.dir)
+}
+
+func (s *SyncTestSuite) writeFile(name string, content string) {
+	file := filepath.Join(s.dir, name)
+	s.Require().NoError(os.MkdirAll(filepath.Dir(file), 0755))
+	s.Require().NoError(ioutil.WriteFile(file, []byte(content), 0644))
+}
+
+func (s *SyncTestSuite) newSyncer() *Syncer {
+	syncer, err := NewSyncer(s.endpoint, Options{LocalDir: s.dir, RemotePath: "/Users/me/project"})
+	s.Require().NoError(err)
+	return syncer
+}
+
+func (s *SyncTestSuite) TestSyncOncePushesNotebooksFilesAndDirectories() {
+	s.writeFile("etl/load.py", "print('hello')")
+	s.writeFile("README.md", "not a notebook")
+
+	gock.New("https://server.com").
+		Post("^/api/2.0/workspace/mkdirs$").
+		BodyString(`"path":"/Users/me/project/etl"`).
+		Reply(200)
+	gock.New("https://server.com").
+		Post("^/api/2.0/workspace/import$").
+		BodyString(`"path":"/Users/me/project/README.md","format":"RAW"`).
+		Reply(200)
+	gock.New("https://server.com").
+		Post("^/api/2.0/workspace/import$").
+		BodyString(`"path":"/Users/me/project/etl/load".*"language":"PYTHON"`).
+		Reply(200)
+
+	result, err := s.newSyncer().SyncOnce()
+	s.Require().NoError(err)
+
+	s.Assert().Equal([]string{"/Users/me/project/etl"}, result.Created)
+	s.Assert().Equal([]string{"/Users/me/project/README.md", "/Users/me/project/etl/load"}, result.Imported)
+	s.Assert().True(gock.IsDone())
+}
+
+func (s *SyncTestSuite) TestSyncOnceSkipsVanishedFiles() {
+	s.writeFile("a.py", "print('a')")
+	s.Require().NoError(os.Symlink(filepath.Join(s.dir, "gone.py"), filepath.Join(s.dir, "link.py")))
+
+	gock.New("https://server.com").
+		Post("^/api/2.0/workspace/import$").
+		BodyString(`"path":"/Users/me/project/a"`).
+		Reply(200)
+
+	result, err := s.newSyncer().SyncOnce()
+	s.Require().NoError(err)
+
+	s.Assert().Equal([]string{"/Users/me/project/a"}, result.Imported)
+	s.Assert().True(gock.IsDone())
+}
+
+func (s *SyncTestSuite) TestLoadStateWithNullMaps() {
+	file := filepath.Join(s.dir, "state.json")
+	s.Require().NoError(ioutil.WriteFile(file, []byte(`{"remote_path":"/x","files":null,"dirs":null}`), 0644))
+
+	state, err := LoadState(file, "/x")
+	s.Require().NoError(err)
+
+	s.Assert().NotNil(state.Files)
+	s.Assert().NotNil(state.Dirs)
+	state.Files["a"] = FileState{}
+	state.Dirs["b"] = true
+}
+
+func (s *SyncTestSuite) TestRestartOnlyPushesChanges() {
+	s.writeFile("a.py", "print('a')")
+	s.writeFile("b.sql", "SELECT 1")
+
+	gock.New("https://server.com").
+		Post("^/api/2.0/workspace/import$").
+		Times(2).
+		Reply(200)
+
+	_, err := s.newSyncer().SyncOnce()
+	s.Require().NoError(err)
+	s.Require().True(gock.IsDone())
+
+	s.writeFile("b.sql", "SELECT 2")
+
+	gock.New("https://server.com").
+		Post("^/api/2.0/workspace/import$").
+		BodyString(`"path":"/Users/me/project/b"`).
+		Reply(200)
+
+	result, err := s.newSyncer().SyncOnce()
+	s.Require().NoError(err)
+
+	s.Assert().Equal([]string{"/Users/me/project/b"}, result.Imported)
+	s.Assert().True(gock.IsDone())
+}
+
+func (s *SyncTestSuite) TestRenameIsDeleteAndImport() {
+	s.writeFile("old.py", "print('a')")
+
+	gock.New("https://server.com").
+		Post("^/api/2.0/workspace/import$").
+		Reply(200)
+
+	syncer := s.newSyncer()
+	_, err := syncer.SyncOnce()
+	s.Require().NoError(err)
+
+	s.Require().NoError(os.Rename(filepath.Join(s.dir, "old.py"), filepath.Join(s.dir, "new.py")))
+
+	gock.New("https://server.com").
+		Post("^/api/2.0/workspace/delete$").
+		BodyString(`"path":"/Users/me/project/old"`).
+		Reply(200)
+	gock.New("https://server.com").
+		Post("^/api/2.0/workspace/import$").
+		BodyString(`"path":"/Users/me/project/new"`).
+		Reply(200)
+
+	result, err := syncer.SyncOnce()
+	s.Require().NoError(err)
+
+	s.Assert().Equal([]string{"/Users/me/project/old"}, result.Deleted)
+	s.Assert().Equal([]string{"/Users/me/project/new"}, result.Imported)
+	s.Assert().True(gock.IsDone())
+}
+
+func TestSyncSuite(t *testing.T) {
+	suite.Run(t, new(SyncTestSuite))
+}